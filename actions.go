@@ -0,0 +1,410 @@
+package gooey
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action is a named input action that InputSources can be bound to using an ActionMap.
+// Besides the built-in actions, which drive gooey's UpdateSettings inputs, any other name can be used for custom actions.
+type Action string
+
+const (
+	ActionLeft   Action = "left"   // Drives UpdateSettings.LeftInput.
+	ActionRight  Action = "right"  // Drives UpdateSettings.RightInput.
+	ActionUp     Action = "up"     // Drives UpdateSettings.UpInput.
+	ActionDown   Action = "down"   // Drives UpdateSettings.DownInput.
+	ActionNext   Action = "next"   // Drives UpdateSettings.NextInput.
+	ActionPrev   Action = "prev"   // Drives UpdateSettings.PrevInput.
+	ActionAccept Action = "accept" // Drives UpdateSettings.AcceptInput.
+	ActionCancel Action = "cancel" // Drives UpdateSettings.CancelInput.
+)
+
+// InputSource is a source of input that can be bound to an Action.
+type InputSource interface {
+	Pressed(c *Context) bool // Returns if the input source is currently pressed or held for the given Context.
+}
+
+// KeySource is an InputSource for a keyboard key, read through the Context's InputProvider.
+type KeySource ebiten.Key
+
+func (k KeySource) Pressed(c *Context) bool {
+	return c.frameInput.IsKeyPressed(ebiten.Key(k))
+}
+
+// GamepadButtonSource is an InputSource for a button on a standard-layout gamepad, read through the Context's InputProvider.
+type GamepadButtonSource struct {
+	Gamepad ebiten.GamepadID
+	Button  ebiten.StandardGamepadButton
+}
+
+func (g GamepadButtonSource) Pressed(c *Context) bool {
+	return c.frameInput.IsStandardGamepadButtonPressed(g.Gamepad, g.Button)
+}
+
+// MouseButtonSource is an InputSource for a mouse button, read through the Context's InputProvider.
+type MouseButtonSource ebiten.MouseButton
+
+func (m MouseButtonSource) Pressed(c *Context) bool {
+	return c.frameInput.IsMouseButtonPressed(ebiten.MouseButton(m))
+}
+
+// InputSourceFunc is an InputSource that calls a function to determine if it's pressed.
+type InputSourceFunc func() bool
+
+func (f InputSourceFunc) Pressed(c *Context) bool {
+	return f()
+}
+
+// RepeatPolicyType specifies how an Action repeats while its input is held.
+type RepeatPolicyType int
+
+const (
+	RepeatPolicyDefault      RepeatPolicyType = iota // Repeat using UpdateSettings.HighlightControlRepeatInitialDelay and HighlightControlRepeatDelay. This is the default behavior.
+	RepeatPolicyNone                                 // Don't repeat; the Action triggers once each time its input is pressed.
+	RepeatPolicyFixed                                // Repeat after RepeatPolicy.InitialDelay, and then every RepeatPolicy.Delay.
+	RepeatPolicyAccelerating                         // Like RepeatPolicyFixed, but the delay between repeats shrinks the longer the input is held.
+)
+
+// RepeatPolicy specifies how an Action repeats while its input is held.
+type RepeatPolicy struct {
+	Type         RepeatPolicyType
+	InitialDelay time.Duration // How long the input needs to be held before it starts repeating. If 0, UpdateSettings.HighlightControlRepeatInitialDelay is used.
+	Delay        time.Duration // How frequently the input repeats after the initial delay. If 0, UpdateSettings.HighlightControlRepeatDelay is used.
+	MinDelay     time.Duration // The shortest delay between repeats for RepeatPolicyAccelerating. Defaults to a 60th of a second.
+	Acceleration float32       // What the delay between repeats is multiplied by with each repeat for RepeatPolicyAccelerating (from 0 to 1). Defaults to 0.8.
+}
+
+// NewFixedRepeatPolicy creates a RepeatPolicy that repeats after the given initial delay, and then every delay.
+func NewFixedRepeatPolicy(initialDelay, delay time.Duration) RepeatPolicy {
+	return RepeatPolicy{
+		Type:         RepeatPolicyFixed,
+		InitialDelay: initialDelay,
+		Delay:        delay,
+	}
+}
+
+// NewAcceleratingRepeatPolicy creates a RepeatPolicy that repeats after the given initial delay, and then with the
+// delay between repeats being multiplied by acceleration for each repeat, down to minDelay.
+func NewAcceleratingRepeatPolicy(initialDelay, delay, minDelay time.Duration, acceleration float32) RepeatPolicy {
+	return RepeatPolicy{
+		Type:         RepeatPolicyAccelerating,
+		InitialDelay: initialDelay,
+		Delay:        delay,
+		MinDelay:     minDelay,
+		Acceleration: acceleration,
+	}
+}
+
+// defaultRepeatPolicy returns the RepeatPolicy an ActionMap uses for the given Action unless another one is set with
+// ActionMap.SetRepeatPolicy(): RepeatPolicyNone for ActionAccept and ActionCancel, so that holding them down doesn't
+// repeat presses, and RepeatPolicyDefault for other Actions.
+func defaultRepeatPolicy(action Action) RepeatPolicy {
+	if action == ActionAccept || action == ActionCancel {
+		return RepeatPolicy{Type: RepeatPolicyNone}
+	}
+	return RepeatPolicy{}
+}
+
+// delays returns the initial delay and the delay until the next repeat after the given number of repeats, and whether the input repeats at all.
+func (p RepeatPolicy) delays(settings UpdateSettings, repeats int) (initialDelay, delay time.Duration, repeat bool) {
+
+	initialDelay = settings.HighlightControlRepeatInitialDelay
+	if initialDelay == 0 {
+		initialDelay = time.Second / 4
+	}
+
+	delay = settings.HighlightControlRepeatDelay
+	if delay == 0 {
+		delay = time.Second / 8
+	}
+
+	switch p.Type {
+
+	case RepeatPolicyNone:
+		return 0, 0, false
+
+	case RepeatPolicyFixed, RepeatPolicyAccelerating:
+
+		if p.InitialDelay > 0 {
+			initialDelay = p.InitialDelay
+		}
+
+		if p.Delay > 0 {
+			delay = p.Delay
+		}
+
+		if p.Type == RepeatPolicyAccelerating {
+
+			acceleration := float64(p.Acceleration)
+			if acceleration <= 0 || acceleration >= 1 {
+				acceleration = 0.8
+			}
+
+			minDelay := p.MinDelay
+			if minDelay <= 0 {
+				minDelay = time.Second / 60
+			}
+
+			delay = time.Duration(float64(delay) * math.Pow(acceleration, float64(repeats)))
+			if delay < minDelay {
+				delay = minDelay
+			}
+
+		}
+
+	}
+
+	return initialDelay, delay, true
+
+}
+
+type actionBinding struct {
+	sources []InputSource
+	repeat  RepeatPolicy
+
+	held      bool
+	triggered bool
+	start     time.Time
+	last      time.Time
+	repeats   int
+}
+
+func (b *actionBinding) update(held bool, now time.Time, settings UpdateSettings) {
+
+	b.triggered = false
+
+	if !held {
+		b.held = false
+		return
+	}
+
+	if !b.held {
+		b.held = true
+		b.triggered = true
+		b.start = now
+		b.last = now
+		b.repeats = 0
+		return
+	}
+
+	initialDelay, delay, repeat := b.repeat.delays(settings, b.repeats)
+
+	if repeat && now.Sub(b.start) >= initialDelay && now.Sub(b.last) >= delay {
+		b.triggered = true
+		b.last = now
+		b.repeats++
+	}
+
+}
+
+// ActionMap binds InputSources (keys, gamepad buttons, mouse buttons, etc) to named Actions, each with its own RepeatPolicy.
+// Set it on a Context with Context.SetActionMap(); each Begin() call then reads the bound sources, driving the built-in
+// Actions' UpdateSettings inputs (in addition to any passed directly) and the custom Actions' states:
+//
+//	actions := gooey.NewActionMap().
+//		Bind(gooey.ActionDown, gooey.KeySource(ebiten.KeyDown), gooey.GamepadButtonSource{0, ebiten.StandardGamepadButtonLeftBottom}).
+//		Bind(gooey.ActionAccept, gooey.KeySource(ebiten.KeyEnter)).
+//		Bind("page-next", gooey.KeySource(ebiten.KeyPageDown)).
+//		SetRepeatPolicy(gooey.ActionDown, gooey.NewAcceleratingRepeatPolicy(0, 0, 0, 0.8))
+//	gooey.SetActionMap(actions)
+//
+// InputRecordings capture which Actions are held each frame (rather than the InputSources themselves), so custom
+// Actions replay along with the built-in ones.
+type ActionMap struct {
+	bindings map[Action]*actionBinding
+	order    []Action
+}
+
+// NewActionMap creates a new, empty ActionMap.
+func NewActionMap() *ActionMap {
+	return &ActionMap{
+		bindings: map[Action]*actionBinding{},
+	}
+}
+
+func (m *ActionMap) binding(action Action) *actionBinding {
+	b, ok := m.bindings[action]
+	if !ok {
+		b = &actionBinding{repeat: defaultRepeatPolicy(action)}
+		m.bindings[action] = b
+		m.order = append(m.order, action)
+	}
+	return b
+}
+
+// Bind binds the given InputSources to the Action, in addition to any already bound to it.
+func (m *ActionMap) Bind(action Action, sources ...InputSource) *ActionMap {
+	b := m.binding(action)
+	b.sources = append(b.sources, sources...)
+	return m
+}
+
+// Unbind removes all InputSources bound to the Action.
+func (m *ActionMap) Unbind(action Action) *ActionMap {
+	m.binding(action).sources = nil
+	return m
+}
+
+// SetRepeatPolicy sets how the Action repeats while its input is held. By default, ActionAccept and ActionCancel don't
+// repeat (RepeatPolicyNone), so that holding them down doesn't repeat presses, and other Actions use RepeatPolicyDefault.
+// Without an ActionMap, all of UpdateSettings' inputs use RepeatPolicyDefault, Accept and Cancel included.
+func (m *ActionMap) SetRepeatPolicy(action Action, policy RepeatPolicy) *ActionMap {
+	m.binding(action).repeat = policy
+	return m
+}
+
+// RepeatPolicy returns the RepeatPolicy for the given Action.
+func (m *ActionMap) RepeatPolicy(action Action) RepeatPolicy {
+	if b, ok := m.bindings[action]; ok {
+		return b.repeat
+	}
+	return defaultRepeatPolicy(action)
+}
+
+// repeatPolicy returns the RepeatPolicy for the given Action from the Context's ActionMap, or RepeatPolicyDefault if the
+// Context has none, so that every input repeats as it always has.
+func (c *Context) repeatPolicy(action Action) RepeatPolicy {
+	if c.actionMap != nil {
+		return c.actionMap.RepeatPolicy(action)
+	}
+	return RepeatPolicy{}
+}
+
+// update reads the bound InputSources, updating the Actions' states and setting the built-in Actions' inputs in the given UpdateSettings.
+func (m *ActionMap) update(c *Context, settings UpdateSettings) UpdateSettings {
+
+	for _, action := range m.order {
+
+		b := m.bindings[action]
+
+		held := false
+
+		if c.replay != nil {
+			held = slices.Contains(c.replay.frame.Actions, action)
+		} else {
+			for _, source := range b.sources {
+				if source.Pressed(c) {
+					held = true
+					break
+				}
+			}
+		}
+
+		switch action {
+		case ActionLeft:
+			settings.LeftInput = settings.LeftInput || held
+		case ActionRight:
+			settings.RightInput = settings.RightInput || held
+		case ActionUp:
+			settings.UpInput = settings.UpInput || held
+		case ActionDown:
+			settings.DownInput = settings.DownInput || held
+		case ActionNext:
+			settings.NextInput = settings.NextInput || held
+		case ActionPrev:
+			settings.PrevInput = settings.PrevInput || held
+		case ActionAccept:
+			settings.AcceptInput = settings.AcceptInput || held
+		case ActionCancel:
+			settings.CancelInput = settings.CancelInput || held
+		}
+
+		b.update(held, c.frameTime, settings)
+
+	}
+
+	return settings
+
+}
+
+// appendHeld appends the Actions that are currently held to the given slice.
+func (m *ActionMap) appendHeld(actions []Action) []Action {
+	for _, action := range m.order {
+		if m.bindings[action].held {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// reset releases all of the Actions, so that they trigger again when next held.
+func (m *ActionMap) reset() {
+	for _, b := range m.bindings {
+		b.held = false
+		b.triggered = false
+	}
+}
+
+// queuedInputAction returns the built-in Action corresponding to the given queued input.
+func queuedInputAction(input int) Action {
+	switch input {
+	case queuedInputLeft:
+		return ActionLeft
+	case queuedInputRight:
+		return ActionRight
+	case queuedInputUp:
+		return ActionUp
+	case queuedInputDown:
+		return ActionDown
+	case queuedInputNext:
+		return ActionNext
+	case queuedInputPrev:
+		return ActionPrev
+	case queuedInputSelect:
+		return ActionAccept
+	case queuedInputCancel:
+		return ActionCancel
+	}
+	return ""
+}
+
+// SetActionMap sets the ActionMap used by the default Context. See Context.SetActionMap().
+func SetActionMap(actionMap *ActionMap) {
+	defaultContext.SetActionMap(actionMap)
+}
+
+// SetActionMap sets the ActionMap the Context reads input from in each Begin() call, and whose RepeatPolicies control
+// how the built-in Actions repeat. The built-in Actions drive the primary player's input (see BeginPlayers()).
+// Note that with an ActionMap set, holding accept or cancel input doesn't repeat it by default; see
+// ActionMap.SetRepeatPolicy(). Set it to nil to only use the UpdateSettings passed to Begin() (the default).
+func (c *Context) SetActionMap(actionMap *ActionMap) {
+	c.actionMap = actionMap
+}
+
+// ActionMap returns the Context's ActionMap, or nil if none is set.
+func (c *Context) ActionMap() *ActionMap {
+	return c.actionMap
+}
+
+// ActionHeld returns if any of the InputSources bound to the given Action are held in the default Context's ActionMap.
+func ActionHeld(action Action) bool {
+	return defaultContext.ActionHeld(action)
+}
+
+// ActionHeld returns if any of the InputSources bound to the given Action are held in the Context's ActionMap.
+func (c *Context) ActionHeld(action Action) bool {
+	if c.actionMap == nil {
+		return false
+	}
+	b, ok := c.actionMap.bindings[action]
+	return ok && b.held
+}
+
+// ActionTriggered returns if the given Action was triggered this frame in the default Context's ActionMap.
+func ActionTriggered(action Action) bool {
+	return defaultContext.ActionTriggered(action)
+}
+
+// ActionTriggered returns if the given Action was triggered this frame in the Context's ActionMap; this is true on
+// the frame its input is pressed, and then again each time it repeats according to the Action's RepeatPolicy.
+func (c *Context) ActionTriggered(action Action) bool {
+	if c.actionMap == nil {
+		return false
+	}
+	b, ok := c.actionMap.bindings[action]
+	return ok && b.triggered
+}
@@ -0,0 +1,75 @@
+package gooey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestRepeatPolicies(t *testing.T) {
+
+	// Each input is held for a second at 60 FPS; by default, inputs repeat after a quarter of a second, and then every
+	// eighth of a second.
+	tests := []struct {
+		name      string
+		actionMap *ActionMap
+		hold      func(settings *UpdateSettings, provider *testInputProvider)
+		pressed   func(ctx *Context) bool
+		want      int
+	}{
+		{"down", nil, func(s *UpdateSettings, p *testInputProvider) { s.DownInput = true }, (*Context).InputPressedDown, 7},
+		{"accept", nil, func(s *UpdateSettings, p *testInputProvider) { s.AcceptInput = true }, (*Context).InputPressedSelect, 7},
+		{"cancel", nil, func(s *UpdateSettings, p *testInputProvider) { s.CancelInput = true }, (*Context).InputPressedCancel, 7},
+		{"accept with empty ActionMap", NewActionMap(),
+			func(s *UpdateSettings, p *testInputProvider) { s.AcceptInput = true }, (*Context).InputPressedSelect, 1},
+		{"cancel with empty ActionMap", NewActionMap(),
+			func(s *UpdateSettings, p *testInputProvider) { s.CancelInput = true }, (*Context).InputPressedCancel, 1},
+		{"down with ActionMap", NewActionMap().Bind(ActionDown, KeySource(ebiten.KeyDown)),
+			func(s *UpdateSettings, p *testInputProvider) { p.keys[ebiten.KeyDown] = true }, (*Context).InputPressedDown, 7},
+		{"accept with ActionMap", NewActionMap().Bind(ActionAccept, KeySource(ebiten.KeyEnter)),
+			func(s *UpdateSettings, p *testInputProvider) { p.keys[ebiten.KeyEnter] = true }, (*Context).InputPressedSelect, 1},
+		{"accept with ActionMap and UpdateSettings", NewActionMap().Bind(ActionAccept, KeySource(ebiten.KeyEnter)),
+			func(s *UpdateSettings, p *testInputProvider) { s.AcceptInput = true }, (*Context).InputPressedSelect, 1},
+		{"accept set to repeat", NewActionMap().SetRepeatPolicy(ActionAccept, RepeatPolicy{}),
+			func(s *UpdateSettings, p *testInputProvider) { s.AcceptInput = true }, (*Context).InputPressedSelect, 7},
+		{"down set not to repeat", NewActionMap().SetRepeatPolicy(ActionDown, RepeatPolicy{Type: RepeatPolicyNone}),
+			func(s *UpdateSettings, p *testInputProvider) { s.DownInput = true }, (*Context).InputPressedDown, 1},
+		{"fixed", NewActionMap().SetRepeatPolicy(ActionDown, NewFixedRepeatPolicy(time.Second/2, time.Second/10)),
+			func(s *UpdateSettings, p *testInputProvider) { s.DownInput = true }, (*Context).InputPressedDown, 6},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			ctx := newTestContext()
+
+			provider := &testInputProvider{keys: map[ebiten.Key]bool{}}
+			ctx.SetInputProvider(provider)
+			ctx.SetActionMap(test.actionMap)
+
+			count := 0
+
+			for frame := 0; frame < 60; frame++ {
+
+				settings := UpdateSettings{}
+				test.hold(&settings, provider)
+
+				testFrame(ctx, func() {
+					if test.pressed(ctx) {
+						count++
+					}
+				}, settings)
+
+			}
+
+			if count != test.want {
+				t.Errorf("input pressed in %d frames, want %d", count, test.want)
+			}
+
+		})
+
+	}
+
+}
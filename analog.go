@@ -0,0 +1,94 @@
+package gooey
+
+import "math"
+
+const (
+	defaultAnalogDeadZone       = 0.25
+	defaultAnalogMaxRepeatSpeed = 4
+)
+
+// analogInput returns the directional input for the analog stick axes in the UpdateSettings, snapped to 4 or 8
+// directions. direction is the snapped direction as a unit vector, and tilt is how far the stick is pushed past the
+// dead zone (from 0 to 1). If the stick is within the dead zone, input is queuedInputNone.
+func (s UpdateSettings) analogInput() (input int, direction Vector2, tilt float32) {
+
+	deadZone := s.AnalogDeadZone
+	if deadZone <= 0 {
+		deadZone = defaultAnalogDeadZone
+	}
+	deadZone = min(deadZone, 0.99)
+
+	stick := Vector2{s.AnalogX, s.AnalogY}
+	magnitude := min(stick.Magnitude(), 1)
+
+	if magnitude < deadZone {
+		return queuedInputNone, Vector2{}, 0
+	}
+
+	tilt = (magnitude - deadZone) / (1 - deadZone)
+
+	sectors := 4.0
+	if s.AnalogEightWay {
+		sectors = 8
+	}
+
+	sector := 2 * math.Pi / sectors
+	angle := math.Round(math.Atan2(float64(stick.Y), float64(stick.X))/sector) * sector
+
+	direction = Vector2{float32(math.Cos(angle)), float32(math.Sin(angle))}
+	if math.Abs(float64(direction.X)) < 0.001 {
+		direction.X = 0
+	}
+	if math.Abs(float64(direction.Y)) < 0.001 {
+		direction.Y = 0
+	}
+
+	// The queued input is always along the stick's dominant axis, so UI elements that respond to directional
+	// input (like UISliders) behave the same with diagonal navigation.
+	if math.Abs(float64(stick.X)) >= math.Abs(float64(stick.Y)) {
+		input = queuedInputRight
+		if stick.X < 0 {
+			input = queuedInputLeft
+		}
+	} else {
+		input = queuedInputDown
+		if stick.Y < 0 {
+			input = queuedInputUp
+		}
+	}
+
+	return input, direction, tilt
+
+}
+
+// analogRepeatSpeed returns how many times faster directional input should repeat for the given stick tilt; the
+// further the stick is pushed, the faster the input repeats.
+func (s UpdateSettings) analogRepeatSpeed(tilt float32) float32 {
+	maxSpeed := s.AnalogMaxRepeatSpeed
+	if maxSpeed <= 0 {
+		maxSpeed = defaultAnalogMaxRepeatSpeed
+	}
+	return 1 + (max(maxSpeed, 1)-1)*tilt
+}
+
+// DiagonalNavigationStrategy is implemented by NavigationStrategies that can navigate diagonally. When analog stick
+// input is snapped to a diagonal direction (see UpdateSettings.AnalogEightWay), NavigateDiagonal() is called with
+// the direction as a unit vector (with +Y being down); if it returns -1, Navigate() is called with the stick's dominant direction.
+// ConeNavigationStrategy implements DiagonalNavigationStrategy.
+type DiagonalNavigationStrategy interface {
+	NavigationStrategy
+	NavigateDiagonal(current int, direction Vector2, candidates []NavigationCandidate) int
+}
+
+// diagonalStrategy adapts a DiagonalNavigationStrategy to navigate in a fixed diagonal direction.
+type diagonalStrategy struct {
+	DiagonalNavigationStrategy
+	direction Vector2
+}
+
+func (s diagonalStrategy) Navigate(current int, direction NavigationInput, candidates []NavigationCandidate) int {
+	if next := s.NavigateDiagonal(current, s.direction, candidates); next >= 0 {
+		return next
+	}
+	return s.DiagonalNavigationStrategy.Navigate(current, direction, candidates)
+}
@@ -0,0 +1,69 @@
+package gooey
+
+import "time"
+
+// referenceFrameTime is the frame time that per-frame values (like Layout.AutoScrollSpeed or
+// UISlider.SliderHeadLerpPercentage) are specified for when UpdateSettings.DeltaTime is set.
+const referenceFrameTime = time.Second / 60
+
+// Clock is an interface for objects that supply the current time to a Context. The time is used for input repeat
+// delays (i.e. UpdateSettings.HighlightControlRepeatInitialDelay and UpdateSettings.HighlightControlRepeatDelay).
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that returns the wall-clock time. This is the default Clock for a Context.
+type SystemClock struct{}
+
+func (s SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a Clock that only changes when advanced manually; this can be useful for testing, or for
+// games that want to pause UI timing.
+type ManualClock struct {
+	current time.Time
+}
+
+// Now returns the ManualClock's current time.
+func (m *ManualClock) Now() time.Time {
+	return m.current
+}
+
+// Advance moves the ManualClock forward by the given duration.
+func (m *ManualClock) Advance(delta time.Duration) {
+	m.current = m.current.Add(delta)
+}
+
+// SetClock sets the Clock the default Context uses for timing. See Context.SetClock().
+func SetClock(clock Clock) {
+	defaultContext.SetClock(clock)
+}
+
+// SetClock sets the Clock the Context uses for timing. Passing nil resets it to SystemClock.
+// Note that the Clock is ignored on frames where UpdateSettings.DeltaTime is set.
+func (c *Context) SetClock(clock Clock) {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	c.clock = clock
+}
+
+// Clock returns the Clock the Context uses for timing.
+func (c *Context) Clock() Clock {
+	return c.clock
+}
+
+// FrameTime returns the time of the current frame for the Context, as determined in Begin().
+func (c *Context) FrameTime() time.Time {
+	return c.frameTime
+}
+
+// deltaScale returns how many reference (1/60th of a second) frames the current frame represents.
+// This is always 1 unless UpdateSettings.DeltaTime is set.
+func (c *Context) deltaScale() float32 {
+	if c.updateSettings.DeltaTime <= 0 {
+		return 1
+	}
+	return float32(c.updateSettings.DeltaTime.Seconds() / referenceFrameTime.Seconds())
+}
@@ -0,0 +1,69 @@
+package gooey
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockDrivesRepeat(t *testing.T) {
+
+	// Down is held for 60 frames; inputs repeat after a quarter of a second, and then every eighth of a second.
+	tests := []struct {
+		name      string
+		advance   time.Duration // How far the ManualClock is advanced each frame
+		deltaTime bool          // Whether frames are run with UpdateSettings.DeltaTime set, which ignores the Clock
+		want      int
+	}{
+		{"paused clock", 0, false, 1},
+		{"60 FPS clock", time.Second / 60, false, 7},
+		{"30 FPS clock", time.Second / 30, false, 14},
+		{"DeltaTime ignores clock", time.Second / 30, true, 7},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			ctx := newTestContext()
+
+			clock := &ManualClock{}
+			ctx.SetClock(clock)
+
+			start := ctx.FrameTime()
+			count := 0
+
+			for frame := 0; frame < 60; frame++ {
+
+				clock.Advance(test.advance)
+
+				countPress := func() {
+					if ctx.InputPressedDown() {
+						count++
+					}
+				}
+
+				if test.deltaTime {
+					testFrame(ctx, countPress, UpdateSettings{DownInput: true})
+				} else {
+					// Without a DeltaTime, the frame time comes from the Context's Clock.
+					ctx.Begin(UpdateSettings{DownInput: true})
+					countPress()
+					ctx.End()
+				}
+
+			}
+
+			if count != test.want {
+				t.Errorf("input pressed in %d frames, want %d", count, test.want)
+			}
+
+			elapsed := ctx.FrameTime().Sub(start)
+			if test.deltaTime && elapsed != 60*testFrameTime {
+				t.Errorf("frame time advanced by %v, want %v", elapsed, 60*testFrameTime)
+			}
+
+		})
+
+	}
+
+}
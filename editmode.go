@@ -0,0 +1,91 @@
+package gooey
+
+// EditModeType specifies how UI elements that use directional input to change their value (i.e. UISliders and
+// UICycleButtons) respond to it when highlighted.
+type EditModeType int
+
+const (
+	EditModeDefault   EditModeType = iota // For UI elements, use the Layout's EditMode; for Layouts, the same as EditModeImmediate. This is the default.
+	EditModeImmediate                     // Directional input adjusts the UI element's value as soon as it's highlighted, so it can't be used to navigate past it.
+	// Accept input enters edit mode for the UI element, after which directional input adjusts its value. Accept input exits
+	// edit mode again, keeping the value, while cancel input exits edit mode and reverts the value to what it was on entering.
+	// Outside of edit mode, directional input navigates as usual.
+	EditModeAccept
+)
+
+// EditEvent indicates a transition into or out of edit mode for a UI element; see DrawCall.UpdateEditMode().
+type EditEvent int
+
+const (
+	EditEventNone   EditEvent = iota // Edit mode wasn't entered or exited this frame.
+	EditEventBegin                   // Edit mode was entered; the UI element should store its value to revert to.
+	EditEventCommit                  // Edit mode was exited, keeping the value.
+	EditEventCancel                  // Edit mode was exited; the UI element should revert its value.
+)
+
+// UpdateEditMode handles entering and exiting edit mode for the UI element being drawn according to the given EditMode
+// (EditModeDefault uses the Layout's EditMode). It returns whether the UI element should respond to directional input
+// this frame, as well as any edit mode transition that happened. Widgets whose value is adjusted with directional input
+// should call this to support EditModeAccept, the same way UISliders do:
+//
+//	adjust, event := dc.UpdateEditMode(w.EditMode)
+//	switch event {
+//	case gooey.EditEventBegin:
+//		state.revertTo = state.value
+//	case gooey.EditEventCancel:
+//		state.value = state.revertTo
+//	}
+//	if adjust && dc.NavigationInput() == gooey.NavigationInputRight {
+//		state.value++
+//		dc.ConsumeNavigationInput()
+//	}
+func (dc *DrawCall) UpdateEditMode(mode EditModeType) (adjust bool, event EditEvent) {
+
+	ctx := dc.Context()
+
+	if mode == EditModeDefault {
+		mode = dc.Instance.layout.EditMode
+	}
+
+	if mode != EditModeAccept {
+		return dc.isHighlighted, EditEventNone
+	}
+
+	editing := ctx.editingElement == dc.Instance
+
+	if !dc.isHighlighted {
+		if editing {
+			ctx.editingElement = nil
+			return false, EditEventCommit
+		}
+		return false, EditEventNone
+	}
+
+	if !editing {
+		if ctx.queuedInput == queuedInputSelect {
+			ctx.editingElement = dc.Instance
+			ctx.queuedInput = queuedInputNone
+			return false, EditEventBegin
+		}
+		return false, EditEventNone
+	}
+
+	switch ctx.queuedInput {
+	case queuedInputSelect:
+		ctx.editingElement = nil
+		ctx.queuedInput = queuedInputNone
+		return false, EditEventCommit
+	case queuedInputCancel:
+		ctx.editingElement = nil
+		ctx.queuedInput = queuedInputNone
+		return false, EditEventCancel
+	}
+
+	return true, EditEventNone
+
+}
+
+// Editing returns if the UI element being drawn is in edit mode (see EditModeAccept).
+func (dc *DrawCall) Editing() bool {
+	return dc.Instance != nil && dc.Context().editingElement == dc.Instance
+}
@@ -0,0 +1,68 @@
+package gooey
+
+import "testing"
+
+func TestEditModeAccept(t *testing.T) {
+
+	ctx := newTestContext()
+
+	volume := float32(0.25)
+
+	frame := func(input UpdateSettings) {
+		testFrame(ctx, func() {
+
+			menu := ctx.NewLayout("menu", 0, 0, 100, 40)
+			menu.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 20}})
+
+			NewUISlider().WithStepSize(0.25).WithEditMode(EditModeAccept).WithPointer(&volume).AddTo(menu, "volume")
+			NewUIWidget(testWidget{}).AddTo(menu, "back")
+
+		}, input)
+	}
+
+	frame(UpdateSettings{})
+
+	steps := []struct {
+		name    string
+		input   UpdateSettings
+		value   float32
+		editing bool
+	}{
+		{"right outside of edit mode", UpdateSettings{RightInput: true}, 0.25, false},
+		{"accept enters edit mode", UpdateSettings{AcceptInput: true}, 0.25, true},
+		{"right adjusts the value", UpdateSettings{RightInput: true}, 0.5, true},
+		{"right adjusts the value again", UpdateSettings{RightInput: true}, 0.75, true},
+		{"cancel reverts the value", UpdateSettings{CancelInput: true}, 0.25, false},
+		{"accept enters edit mode again", UpdateSettings{AcceptInput: true}, 0.25, true},
+		{"left adjusts the value", UpdateSettings{LeftInput: true}, 0, true},
+		{"accept keeps the value", UpdateSettings{AcceptInput: true}, 0, false},
+	}
+
+	for _, step := range steps {
+
+		// A frame without input in between, so that each step is a fresh press rather than a held one.
+		frame(step.input)
+		frame(UpdateSettings{})
+
+		if volume != step.value {
+			t.Errorf("%s: slider value is %v, want %v", step.name, volume, step.value)
+		}
+
+		if editing := ctx.editingElement != nil; editing != step.editing {
+			t.Errorf("%s: editing is %t, want %t", step.name, editing, step.editing)
+		}
+
+		if h := ctx.HighlightedUIElement(); h == nil || h.id != "volume" {
+			t.Errorf("%s: highlighted %v, want volume", step.name, h.ref())
+		}
+
+	}
+
+	// Outside of edit mode, directional input navigates past the slider again.
+	frame(UpdateSettings{DownInput: true})
+
+	if h := ctx.HighlightedUIElement(); h == nil || h.id != "back" {
+		t.Errorf("highlighted %v after pressing down, want back", h.ref())
+	}
+
+}
@@ -0,0 +1,166 @@
+package gooey
+
+// ElementRef identifies a UI element by its Layout and ID.
+type ElementRef struct {
+	Layout *Layout
+	ID     string
+	Hash   uint64 // The UI element's ID hashed with the ID scope it was drawn in (see Layout.PushID()); unlike ID, this is unique within the Layout.
+}
+
+// IsZero returns if the ElementRef doesn't refer to a UI element.
+func (r ElementRef) IsZero() bool {
+	return r.Layout == nil
+}
+
+func (u *uiElementInstance) ref() ElementRef {
+	if u == nil {
+		return ElementRef{}
+	}
+	return ElementRef{Layout: u.layout, ID: u.id, Hash: u.hash}
+}
+
+// Ref returns an ElementRef for the UI element with the given ID in the Layout's current ID scope (see PushID()),
+// whether or not the UI element exists. This can be compared to the ElementRefs passed to callbacks and events.
+func (l *Layout) Ref(id string) ElementRef {
+	return ElementRef{Layout: l, ID: id, Hash: hashIDString(l.idScope(), id)}
+}
+
+// EventType indicates the kind of an Event.
+type EventType int
+
+const (
+	EventHighlightChanged EventType = iota // The highlight moved from Event.Previous to Event.Element; either can be zero if nothing was or is highlighted.
+	EventHighlighted                       // Event.Element was highlighted.
+	EventUnhighlighted                     // Event.Element was unhighlighted.
+	EventPressed                           // Event.Element (a UIButton or a Widget calling DrawCall.NotifyPressed()) was pressed.
+	EventCancel                            // Cancel input was pressed without being consumed by a UI element; Event.Element is the highlighted UI element, if any.
+)
+
+// Event is something that happened in a Context over the course of a frame. See Context.DrainEvents().
+// Highlight events (and ElementCallbacks' OnHighlighted and OnUnhighlighted functions) follow the highlight that's moved
+// by directional input and Highlight(); hovering the mouse over a UI element or touching it doesn't highlight it in this
+// sense, and so doesn't emit them. Widgets can use DrawCall.Hovered() to react to the mouse instead.
+type Event struct {
+	Type     EventType
+	Element  ElementRef
+	Previous ElementRef // The previously highlighted UI element for EventHighlightChanged.
+	Player   int        // The ID of the player whose highlight or input caused the event; see Context.BeginPlayers().
+}
+
+// ElementCallbacks are functions called when specific events happen to a UI element. See Layout.SetCallbacks().
+type ElementCallbacks struct {
+	OnHighlighted   func() // Called when the UI element is highlighted (but not when the mouse hovers over it; see Event).
+	OnUnhighlighted func() // Called when the UI element stops being highlighted.
+	OnPressed       func() // Called when the UI element is pressed.
+}
+
+// SetCallbacks sets the functions to call when events happen to the UI element with the given ID in the Layout:
+//
+//	layout.SetCallbacks("start", gooey.ElementCallbacks{
+//		OnHighlighted: func() { description = "Start a new game." },
+//		OnPressed:     func() { playSound("start") },
+//	})
+//
+// The ID is in the Layout's current ID scope (see PushID()), so callbacks can be set for UI elements drawn by reusable components.
+func (l *Layout) SetCallbacks(id string, callbacks ElementCallbacks) {
+	if l.callbacks == nil {
+		l.callbacks = map[uint64]ElementCallbacks{}
+	}
+	l.callbacks[hashIDString(l.idScope(), id)] = callbacks
+}
+
+func (u *uiElementInstance) callbacks() ElementCallbacks {
+	return u.layout.callbacks[u.hash]
+}
+
+// DrainEvents returns the events that happened in the default Context since the last Begin() call. See Context.DrainEvents().
+func DrainEvents() []Event {
+	return defaultContext.DrainEvents()
+}
+
+// DrainEvents returns the events that happened in the Context since the last Begin() call (or the last call to DrainEvents()),
+// in the order they happened, and clears them. Call it after End() to react to the frame's events:
+//
+//	for _, e := range ctx.DrainEvents() {
+//		if e.Type == gooey.EventHighlightChanged {
+//			playSound("blip")
+//		}
+//	}
+//
+// Events that aren't drained are discarded when Begin() is next called.
+func (c *Context) DrainEvents() []Event {
+	events := c.events
+	c.events = nil
+	return events
+}
+
+// updateHighlightEvents emits events and calls callbacks if the highlighted UI element changed since the last time it was called.
+func (c *Context) updateHighlightEvents() {
+
+	prev := c.reportedHighlight
+	next := c.highlightedElement
+
+	if prev == next {
+		return
+	}
+
+	c.reportedHighlight = next
+
+	if prev != nil {
+		c.events = append(c.events, Event{Type: EventUnhighlighted, Element: prev.ref(), Player: c.playerState.id})
+		if f := prev.callbacks().OnUnhighlighted; f != nil {
+			f()
+		}
+	}
+
+	if next != nil {
+		c.events = append(c.events, Event{Type: EventHighlighted, Element: next.ref(), Player: c.playerState.id})
+		if f := next.callbacks().OnHighlighted; f != nil {
+			f()
+		}
+	}
+
+	c.events = append(c.events, Event{Type: EventHighlightChanged, Element: next.ref(), Previous: prev.ref(), Player: c.playerState.id})
+
+	if c.OnHighlightChanged != nil {
+		c.OnHighlightChanged(prev.ref(), next.ref())
+	}
+
+}
+
+// pressed emits an event and calls callbacks for the given UI element being pressed.
+func (c *Context) pressed(inst *uiElementInstance) {
+
+	c.lastPressed = inst
+	c.lastPressedPlayer = c.playerState.id
+
+	c.events = append(c.events, Event{Type: EventPressed, Element: inst.ref(), Player: c.playerState.id})
+
+	if f := inst.callbacks().OnPressed; f != nil {
+		f()
+	}
+
+	if c.OnPressed != nil {
+		c.OnPressed(inst.ref())
+	}
+
+}
+
+// cancelled emits an event and calls callbacks for cancel input being pressed, and then pops the navigation stack.
+func (c *Context) cancelled() {
+
+	c.events = append(c.events, Event{Type: EventCancel, Element: c.highlightedElement.ref(), Player: c.playerState.id})
+
+	if c.OnCancel != nil {
+		c.OnCancel(c.highlightedElement.ref())
+	}
+
+	c.cancelNavigation()
+
+}
+
+// NotifyPressed informs the Context that the UI element being drawn was pressed, emitting an EventPressed event and
+// calling the OnPressed callbacks. Widgets that can be pressed like buttons should call this when they are.
+func (dc *DrawCall) NotifyPressed() {
+	dc.Context().pressed(dc.Instance)
+}
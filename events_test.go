@@ -0,0 +1,44 @@
+package gooey
+
+import "testing"
+
+func TestCallbacksAreScoped(t *testing.T) {
+
+	ctx := newTestContext()
+
+	highlighted := map[int]int{}
+	pressed := map[int]int{}
+
+	// Two copies of a dialog with the same button IDs in different scopes; the first one is highlighted and pressed.
+	for frame := 0; frame < 4; frame++ {
+
+		testFrame(ctx, func() {
+
+			layout := ctx.NewLayout("dialogs", 0, 0, 200, 200)
+
+			for i := 0; i < 2; i++ {
+				layout.PushIDInt(i)
+				layout.SetCallbacks("ok", ElementCallbacks{
+					OnHighlighted: func() { highlighted[i]++ },
+					OnPressed:     func() { pressed[i]++ },
+				})
+				NewUIButton().AddTo(layout, "ok")
+				if frame == 0 && i == 0 {
+					ctx.Highlight(layout, "ok")
+				}
+				layout.PopID()
+			}
+
+		}, UpdateSettings{AcceptInput: frame == 2})
+
+	}
+
+	if highlighted[0] != 1 || highlighted[1] != 0 {
+		t.Errorf("OnHighlighted called %d times for the first dialog and %d times for the second, want 1 and 0", highlighted[0], highlighted[1])
+	}
+
+	if pressed[0] != 1 || pressed[1] != 0 {
+		t.Errorf("OnPressed called %d times for the first dialog and %d times for the second, want 1 and 0", pressed[0], pressed[1])
+	}
+
+}
@@ -0,0 +1,187 @@
+package gooey
+
+// Remove removes the UI element instance with the given ID (in the Layout's current ID scope) from the Layout,
+// along with any child instances it created (e.g. a UIButton's graphics), discarding their states, as well as any
+// navigation neighbors or callbacks set for them.
+// If the Context's OnEvicted function is set, it's called for each removed instance.
+// Returns whether an instance with the given ID existed.
+func (l *Layout) Remove(id string) bool {
+
+	target := l.existingUIElements.Data[hashIDString(l.idScope(), id)]
+
+	if target == nil {
+		return false
+	}
+
+	l.removeInstances(func(inst *uiElementInstance) bool {
+		for i := inst; i != nil; i = i.parent {
+			if i == target {
+				return true
+			}
+		}
+		return false
+	})
+
+	return true
+
+}
+
+// removeInstances removes every UI element instance from the Layout for which the given function returns true.
+func (l *Layout) removeInstances(remove func(inst *uiElementInstance) bool) {
+
+	removed := []*uiElementInstance{}
+
+	l.existingUIElements.RemoveFunc(func(inst *uiElementInstance) bool {
+		if remove(inst) {
+			removed = append(removed, inst)
+			return true
+		}
+		return false
+	})
+
+	for _, inst := range removed {
+		delete(l.neighbors, inst.hash)
+		delete(l.callbacks, inst.hash)
+		l.context.forgetInstance(inst)
+	}
+
+}
+
+// forgetInstance removes any references the Context has to a UI element instance that was removed from its Layout.
+func (c *Context) forgetInstance(inst *uiElementInstance) {
+
+	for _, p := range c.players {
+
+		if p.highlightedElement == inst {
+			p.highlightedElement = nil
+		}
+
+		// The instance is gone, so it doesn't get an EventUnhighlighted.
+		if p.reportedHighlight == inst {
+			p.reportedHighlight = nil
+		}
+
+		if p.editingElement == inst {
+			p.editingElement = nil
+		}
+
+		if p.lastPressed == inst {
+			p.lastPressed = nil
+		}
+
+		for i := 0; i < len(p.rememberCache); i++ {
+			if p.rememberCache[i].Instance == inst {
+				p.rememberCache = append(p.rememberCache[:i], p.rememberCache[i+1:]...)
+				break
+			}
+		}
+
+	}
+
+	for _, entry := range c.navigationStack {
+		if entry.opener == inst {
+			entry.opener = nil
+		}
+	}
+
+	// Let go of any touch or mouse drag the instance claimed, so that it can scroll the Layout instead.
+	for _, t := range c.touches {
+		if t.owner == inst {
+			t.owner = nil
+		}
+	}
+
+	if c.mouseDrag != nil && c.mouseDrag.owner == inst {
+		c.mouseDrag.owner = nil
+	}
+
+	if c.OnEvicted != nil {
+		c.OnEvicted(inst.ref(), inst.state)
+	}
+
+}
+
+// DestroyLayout removes the Layout with the given ID from the default Context. See Context.DestroyLayout().
+func DestroyLayout(id string) bool {
+	return defaultContext.DestroyLayout(id)
+}
+
+// DestroyLayout removes the Layout with the given ID from the Context, along with all of its UI element instances
+// and their states. If the Context's OnEvicted function is set, it's called for each removed instance.
+// Calling NewLayout() with the same ID afterwards creates a new, empty Layout.
+// Returns whether a Layout with the given ID existed.
+func (c *Context) DestroyLayout(id string) bool {
+	for _, l := range c.existingLayouts {
+		if l.ID == id {
+			c.destroyLayout(l)
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Context) destroyLayout(layout *Layout) {
+
+	layout.removeInstances(func(inst *uiElementInstance) bool { return true })
+
+	// Drop neighbors and callbacks set for UI elements that were never drawn, too.
+	layout.neighbors = nil
+	layout.callbacks = nil
+
+	for _, p := range c.players {
+		if p.pendingHighlightLayout == layout {
+			p.pendingHighlightLayout = nil
+		}
+	}
+
+	for i, l := range c.existingLayouts {
+		if l == layout {
+			c.existingLayouts = append(c.existingLayouts[:i], c.existingLayouts[i+1:]...)
+			break
+		}
+	}
+
+	for i, l := range c.visibleLayouts {
+		if l == layout {
+			c.visibleLayouts = append(c.visibleLayouts[:i], c.visibleLayouts[i+1:]...)
+			break
+		}
+	}
+
+	for idBase, layouts := range c.layoutsFromStrings {
+		for _, l := range layouts {
+			if l == layout {
+				delete(c.layoutsFromStrings, idBase)
+				break
+			}
+		}
+	}
+
+}
+
+// evictStale removes Layouts and UI element instances that haven't been drawn for more than the Context's
+// EvictAfterFrames setting.
+func (c *Context) evictStale() {
+
+	if c.EvictAfterFrames <= 0 {
+		return
+	}
+
+	limit := uint64(c.EvictAfterFrames)
+
+	for i := len(c.existingLayouts) - 1; i >= 0; i-- {
+
+		layout := c.existingLayouts[i]
+
+		if c.drawFrame-layout.visibleFrame > limit {
+			c.destroyLayout(layout)
+			continue
+		}
+
+		layout.removeInstances(func(inst *uiElementInstance) bool {
+			return c.drawFrame-inst.drawnFrame > limit
+		})
+
+	}
+
+}
@@ -0,0 +1,171 @@
+package gooey
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestRemove(t *testing.T) {
+
+	ctx := newTestContext()
+
+	evicted := []string{}
+	ctx.OnEvicted = func(element ElementRef, state any) { evicted = append(evicted, element.ID) }
+
+	var menu *Layout
+
+	// draw draws the menu, with "b" only drawn if drawB is true.
+	draw := func(drawB bool) func() {
+		return func() {
+
+			menu = ctx.NewLayout("menu", 0, 0, 100, 120)
+			menu.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+			NewUIButton().AddTo(menu, "a")
+
+			if drawB {
+				menu.SetNeighbors("b", NavigationNeighbors{Down: NavigationTarget{ID: "a"}})
+				menu.SetCallbacks("b", ElementCallbacks{OnPressed: func() {}})
+				NewUIButton().AddTo(menu, "b")
+				ctx.Highlight(menu, "b")
+			}
+
+		}
+	}
+
+	testFrame(ctx, draw(true))
+
+	if !menu.Remove("b") {
+		t.Fatal(`Remove("b") returned false`)
+	}
+
+	if menu.Remove("b") {
+		t.Error(`Remove("b") returned true for an already removed UI element`)
+	}
+
+	if !slices.Equal(evicted, []string{"b"}) {
+		t.Errorf("evicted %v, want [b]", evicted)
+	}
+
+	if ctx.HighlightedUIElement() != nil {
+		t.Error("the removed UI element is still highlighted")
+	}
+
+	hash := hashIDString(idHashSeed, "b")
+
+	if _, ok := menu.neighbors[hash]; ok {
+		t.Error("the removed UI element's neighbors weren't removed")
+	}
+
+	if _, ok := menu.callbacks[hash]; ok {
+		t.Error("the removed UI element's callbacks weren't removed")
+	}
+
+	ctx.DrainEvents()
+
+	testFrame(ctx, draw(false))
+
+	for _, e := range ctx.DrainEvents() {
+		if e.Element.ID == "b" || e.Previous.ID == "b" {
+			t.Errorf("event %+v refers to the removed UI element", e)
+		}
+	}
+
+}
+
+func TestDestroyLayout(t *testing.T) {
+
+	ctx := newTestContext()
+
+	evicted := []string{}
+	ctx.OnEvicted = func(element ElementRef, state any) { evicted = append(evicted, element.ID) }
+
+	var menu *Layout
+
+	testFrame(ctx, func() {
+		menu = ctx.NewLayout("menu", 0, 0, 100, 120)
+		menu.SetCallbacks("never drawn", ElementCallbacks{OnPressed: func() {}})
+		NewUIWidget(testWidget{}).AddTo(menu, "a")
+		NewUIWidget(testWidget{}).AddTo(menu, "b")
+	})
+
+	if !ctx.DestroyLayout("menu") {
+		t.Fatal(`DestroyLayout("menu") returned false`)
+	}
+
+	if ctx.DestroyLayout("menu") {
+		t.Error(`DestroyLayout("menu") returned true for an already destroyed Layout`)
+	}
+
+	if !slices.Equal(evicted, []string{"a", "b"}) {
+		t.Errorf("evicted %v, want [a b]", evicted)
+	}
+
+	if len(menu.callbacks) > 0 {
+		t.Error("the destroyed Layout's callbacks weren't removed")
+	}
+
+	testFrame(ctx, func() {
+		if l := ctx.NewLayout("menu", 0, 0, 100, 120); l == menu || l.UIElement("a") != nil {
+			t.Error("NewLayout() returned the destroyed Layout")
+		}
+	})
+
+}
+
+func TestEvictStale(t *testing.T) {
+
+	ctx := newTestContext()
+	ctx.EvictAfterFrames = 2
+
+	evicted := []string{}
+	ctx.OnEvicted = func(element ElementRef, state any) { evicted = append(evicted, element.Layout.ID+"/"+element.ID) }
+
+	var inventory *Layout
+
+	// Five items are drawn in the first frame, and then only two; the popup Layout is only drawn in the first frame.
+	for frame := 0; frame < 5; frame++ {
+
+		testFrame(ctx, func() {
+
+			inventory = ctx.NewLayout("inventory", 0, 0, 100, 200)
+			inventory.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+			count := 2
+			if frame == 0 {
+				count = 5
+				NewUIWidget(testWidget{}).AddTo(ctx.NewLayout("popup", 200, 0, 100, 100), "ok")
+			}
+
+			for i := 0; i < count; i++ {
+				inventory.SetCallbacks(fmt.Sprint("item", i), ElementCallbacks{OnPressed: func() {}})
+				NewUIWidget(testWidget{}).AddTo(inventory, fmt.Sprint("item", i))
+			}
+
+		})
+
+	}
+
+	want := []string{"inventory/item2", "inventory/item3", "inventory/item4", "popup/ok"}
+	slices.Sort(evicted)
+
+	if !slices.Equal(evicted, want) {
+		t.Errorf("evicted %v, want %v", evicted, want)
+	}
+
+	if count := len(inventory.existingUIElements.Data); count != 2 {
+		t.Errorf("%d UI element instances left, want 2", count)
+	}
+
+	if count := len(inventory.callbacks); count != 2 {
+		t.Errorf("callbacks left for %d UI elements, want 2", count)
+	}
+
+	for _, l := range ctx.existingLayouts {
+		if l.ID == "popup" {
+			t.Error("the popup Layout wasn't evicted")
+		}
+	}
+
+}
@@ -0,0 +1,77 @@
+package gooey
+
+// Highlight highlights the UI element with the given ID in the given Layout in the default Context. See Context.Highlight().
+func Highlight(layout *Layout, id string) {
+	defaultContext.Highlight(layout, id)
+}
+
+// Highlight highlights the UI element with the given ID (in the Layout's current ID scope; see Layout.PushID()) in the given Layout,
+// switching the Context from mouse to directional input. This is useful to highlight a specific button when a dialog opens,
+// for example.
+// If the UI element has already been drawn, it's highlighted immediately. Otherwise (e.g. if the dialog it's in is
+// drawn for the first time this frame), it's highlighted at the end of the frame, provided it's been drawn by then and
+// is highlightable.
+func (c *Context) Highlight(layout *Layout, id string) {
+
+	hash := hashIDString(layout.idScope(), id)
+
+	c.usingMouse = false
+	c.usingTouch = false
+	c.highlightCleared = false
+	c.pendingHighlightLayout = nil
+	c.pendingRestore = false
+
+	if element, ok := layout.existingUIElements.Data[hash]; ok && element.drawable.highlightable() {
+		c.highlightedElement = element
+		return
+	}
+
+	c.pendingHighlightLayout = layout
+	c.pendingHighlightHash = hash
+
+}
+
+// ClearHighlight clears the highlight in the default Context. See Context.ClearHighlight().
+func ClearHighlight() {
+	defaultContext.ClearHighlight()
+}
+
+// ClearHighlight clears the Context's highlight, so that no UI element is highlighted. Unlike setting the highlighted
+// element to nil, a default UI element isn't automatically highlighted again until directional input is pressed.
+func (c *Context) ClearHighlight() {
+	c.highlightedElement = nil
+	c.pendingHighlightLayout = nil
+	c.highlightCleared = true
+}
+
+// IsHighlighted returns if the UI element with the given ID in the given Layout is highlighted in the default Context.
+func IsHighlighted(layout *Layout, id string) bool {
+	return defaultContext.IsHighlighted(layout, id)
+}
+
+// IsHighlighted returns if the UI element with the given ID (in the Layout's current ID scope) in the given Layout is
+// currently highlighted in the Context.
+func (c *Context) IsHighlighted(layout *Layout, id string) bool {
+	return c.highlightedElement != nil && c.highlightedElement.layout == layout && c.highlightedElement.hash == hashIDString(layout.idScope(), id)
+}
+
+// resolvePendingHighlight highlights the UI element requested through Highlight() (or restored by Page.MakeActive())
+// if it's been drawn since.
+func (c *Context) resolvePendingHighlight() {
+
+	if c.pendingHighlightLayout == nil {
+		return
+	}
+
+	if c.pendingRestore {
+		if element := c.restoreHighlight(c.pendingHighlightLayout); element != nil {
+			c.highlightedElement = element
+		}
+	} else if element, ok := c.pendingHighlightLayout.existingUIElements.Data[c.pendingHighlightHash]; ok && element.wasDrawn && element.drawable.highlightable() {
+		c.highlightedElement = element
+	}
+
+	c.pendingHighlightLayout = nil
+	c.pendingRestore = false
+
+}
@@ -0,0 +1,87 @@
+package gooey
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+
+	ctx := newTestContext()
+
+	var layout *Layout
+	dialog := false
+
+	// frame runs a frame with a column of "a", "b" and a disabled "c", followed by "ok" once the dialog is open; before
+	// is called once the Layout exists but before any UI elements have been added to it, and after once they all have been.
+	frame := func(input UpdateSettings, before, after func()) {
+		testFrame(ctx, func() {
+			layout = ctx.NewLayout("menu", 0, 0, 40, 160)
+			layout.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+			if before != nil {
+				before()
+			}
+			NewUIWidget(testWidget{}).AddTo(layout, "a")
+			NewUIWidget(testWidget{}).AddTo(layout, "b")
+			NewUIWidget(testWidget{disabled: true}).AddTo(layout, "c")
+			if dialog {
+				NewUIWidget(testWidget{}).AddTo(layout, "ok")
+			}
+			if after != nil {
+				after()
+			}
+		}, input)
+	}
+
+	frame(UpdateSettings{}, nil, nil)
+
+	if !ctx.IsHighlighted(layout, "a") {
+		t.Fatalf("highlighted %v by default, want a", ctx.HighlightedUIElement())
+	}
+
+	// An element that's already been drawn is highlighted immediately.
+	frame(UpdateSettings{}, nil, func() {
+		ctx.Highlight(layout, "b")
+		if !ctx.IsHighlighted(layout, "b") {
+			t.Errorf("b isn't highlighted right after Highlight()")
+		}
+	})
+
+	// An element that's never been drawn before is highlighted at the end of the frame it's first drawn in.
+	dialog = true
+
+	frame(UpdateSettings{}, func() {
+		ctx.Highlight(layout, "ok")
+		if ctx.IsHighlighted(layout, "ok") {
+			t.Errorf("ok is highlighted before it's been drawn")
+		}
+	}, nil)
+
+	if !ctx.IsHighlighted(layout, "ok") {
+		t.Errorf("highlighted %v after a pending Highlight(), want ok", ctx.HighlightedUIElement())
+	}
+
+	// Elements that are never drawn, or that can't be highlighted, don't take the highlight.
+	frame(UpdateSettings{}, nil, func() {
+		ctx.Highlight(layout, "missing")
+		ctx.Highlight(layout, "c")
+	})
+
+	if !ctx.IsHighlighted(layout, "ok") {
+		t.Errorf("highlighted %v after highlighting a missing and a disabled element, want ok", ctx.HighlightedUIElement())
+	}
+
+	// A cleared highlight stays cleared until directional input is pressed.
+	frame(UpdateSettings{}, nil, ctx.ClearHighlight)
+	frame(UpdateSettings{}, nil, nil)
+
+	for _, id := range []string{"a", "b", "c", "ok"} {
+		if ctx.IsHighlighted(layout, id) {
+			t.Errorf("%s is highlighted after ClearHighlight()", id)
+		}
+	}
+
+	frame(UpdateSettings{DownInput: true}, nil, nil)
+
+	if ctx.HighlightedUIElement() == nil {
+		t.Errorf("nothing is highlighted after pressing down")
+	}
+
+}
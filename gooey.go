@@ -3,8 +3,10 @@ package gooey
 import (
 	"errors"
 	"fmt"
+	"image"
 	"image/color"
 	"log"
+	"slices"
 	"sort"
 	"time"
 
@@ -28,13 +30,10 @@ const (
 // UIIDReusePolicy sets what should happen when an ID is used more than once in a single frame. The default behavior is to panic.
 var UIIDReusePolicy UIIDReusePolicyType
 
-var screenBuffer *ebiten.Image
 var defaultFont text.Face = text.NewGoXFace(basicfont.Face7x13)
 
-var visibleLayouts = []*Layout{}
-var existingLayouts = []*Layout{}
-var IDsInUse = []any{}
-
+// ScrollWheelScrollSpeed is how fast the mouse wheel scrolls the Layout under the cursor; at 1 (the default), each
+// notch of the wheel scrolls 16 pixels.
 var ScrollWheelScrollSpeed = float32(1)
 
 //go:embed text.kage
@@ -62,31 +61,144 @@ func init() {
 
 }
 
-// Init initializes the screen buffer; this should only need to be called once in an application, or whenever you need to resize the UI.
+// Init initializes the default Context's screen buffer; this should only need to be called once in an application, or whenever you need to resize the UI.
 func Init(w, h int) {
+	defaultContext.Init(w, h)
+}
+
+// Init initializes the Context's screen buffer; this should only need to be called once for each Context, or whenever you need to resize the UI.
+func (c *Context) Init(w, h int) {
 
-	if screenBuffer != nil {
+	if c.screenBuffer != nil {
 
-		if bounds := screenBuffer.Bounds(); bounds.Dx() == w && bounds.Dy() == h {
+		if bounds := c.screenBuffer.Bounds(); bounds.Dx() == w && bounds.Dy() == h {
 			return
 		}
-		screenBuffer.Deallocate()
+		c.screenBuffer.Deallocate()
 
 	}
 
-	screenBuffer = ebiten.NewImage(w, h)
-	existingLayouts = existingLayouts[:0]
-	clear(layoutsFromStrings)
+	c.screenBuffer = ebiten.NewImage(w, h)
+	c.existingLayouts = c.existingLayouts[:0]
+	clear(c.layoutsFromStrings)
 
 }
 
-// Texture returns the rendered texture for all UI elements.
+// Texture returns the rendered texture for all UI elements in the default Context.
 func Texture() *ebiten.Image {
-	return screenBuffer
+	return defaultContext.Texture()
+}
+
+// Texture returns the rendered texture for all UI elements in the Context.
+func (c *Context) Texture() *ebiten.Image {
+	return c.screenBuffer
+}
+
+// SetPresentationGeoM registers the GeoM used to draw the default Context's Texture() to the screen.
+// See Context.SetPresentationGeoM() for more information.
+func SetPresentationGeoM(geoM ebiten.GeoM) {
+	defaultContext.SetPresentationGeoM(geoM)
+}
+
+// SetPresentationGeoM registers the GeoM used to draw the Context's Texture() to the screen (e.g. when scaling
+// and letterboxing the UI to fit the window). The cursor is transformed through the inverse of this GeoM so that
+// hovering and clicking line up with UI elements in the screen buffer. If the GeoM isn't invertible, it's ignored.
+func (c *Context) SetPresentationGeoM(geoM ebiten.GeoM) {
+	c.presentationViewport = nil
+	c.setPresentationGeoM(geoM)
+}
+
+func (c *Context) setPresentationGeoM(geoM ebiten.GeoM) {
+	if !geoM.IsInvertible() {
+		return
+	}
+	c.presentationGeoM = geoM
+	c.presentationInverse = geoM
+	c.presentationInverse.Invert()
+}
+
+// SetPresentationViewport registers the rectangle on the screen that the default Context's Texture() is stretched to fill.
+// See Context.SetPresentationViewport() for more information.
+func SetPresentationViewport(viewport Rect) {
+	defaultContext.SetPresentationViewport(viewport)
+}
+
+// SetPresentationViewport registers the rectangle on the screen that the Context's Texture() is stretched to fill
+// when drawn. This is a convenience function that sets the presentation GeoM accordingly (see SetPresentationGeoM());
+// it can be called before Init(), and the GeoM is kept up to date if Init() resizes the screen buffer afterwards.
+func (c *Context) SetPresentationViewport(viewport Rect) {
+	c.presentationViewport = &viewport
+	c.presentationSize = image.Point{}
+}
+
+// updatePresentation recomputes the presentation GeoM from the presentation viewport, if one is set and the screen
+// buffer's size has changed since the GeoM was last computed.
+func (c *Context) updatePresentation() {
+
+	if c.presentationViewport == nil || c.screenBuffer == nil {
+		return
+	}
+
+	size := c.screenBuffer.Bounds().Size()
+
+	if size == c.presentationSize || size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	c.presentationSize = size
+
+	viewport := c.presentationViewport
+	geoM := ebiten.GeoM{}
+	geoM.Scale(float64(viewport.W)/float64(size.X), float64(viewport.H)/float64(size.Y))
+	geoM.Translate(float64(viewport.X), float64(viewport.Y))
+	c.setPresentationGeoM(geoM)
+
+}
+
+// PresentationGeoM returns the GeoM registered to draw the default Context's Texture() to the screen.
+func PresentationGeoM() ebiten.GeoM {
+	return defaultContext.PresentationGeoM()
+}
+
+// PresentationGeoM returns the GeoM registered to draw the Context's Texture() to the screen.
+func (c *Context) PresentationGeoM() ebiten.GeoM {
+	c.updatePresentation()
+	return c.presentationGeoM
+}
+
+// ScreenToBuffer converts a point on the screen to a point in the default Context's screen buffer.
+func ScreenToBuffer(x, y float32) Vector2 {
+	return defaultContext.ScreenToBuffer(x, y)
+}
+
+// ScreenToBuffer converts a point on the screen to a point in the Context's screen buffer, using the inverse
+// of the presentation GeoM (see SetPresentationGeoM()).
+func (c *Context) ScreenToBuffer(x, y float32) Vector2 {
+	c.updatePresentation()
+	bx, by := c.presentationInverse.Apply(float64(x), float64(y))
+	return Vector2{float32(bx), float32(by)}
+}
+
+// BufferToScreen converts a point in the default Context's screen buffer to a point on the screen.
+func BufferToScreen(x, y float32) Vector2 {
+	return defaultContext.BufferToScreen(x, y)
 }
 
-// DrawDebug will draw debug elements.
+// BufferToScreen converts a point in the Context's screen buffer to a point on the screen using the
+// presentation GeoM (see SetPresentationGeoM()).
+func (c *Context) BufferToScreen(x, y float32) Vector2 {
+	c.updatePresentation()
+	sx, sy := c.presentationGeoM.Apply(float64(x), float64(y))
+	return Vector2{float32(sx), float32(sy)}
+}
+
+// DrawDebug will draw debug elements for the default Context.
 func DrawDebug(screen *ebiten.Image, drawAreaText bool) {
+	defaultContext.DrawDebug(screen, drawAreaText)
+}
+
+// DrawDebug will draw debug elements for the Context.
+func (c *Context) DrawDebug(screen *ebiten.Image, drawAreaText bool) {
 
 	drawText := func(x, y float32, txt string) {
 		opt := &text.DrawOptions{}
@@ -98,7 +210,7 @@ func DrawDebug(screen *ebiten.Image, drawAreaText bool) {
 		text.Draw(screen, txt, defaultFont, opt)
 	}
 
-	for _, layout := range visibleLayouts {
+	for _, layout := range c.visibleLayouts {
 
 		x := layout.Rect.X
 		y := layout.Rect.Y
@@ -142,8 +254,25 @@ type UpdateSettings struct {
 	AcceptInput bool // Selecting ("clicking") a UI element
 	CancelInput bool // Pressing cancel
 
+	// AnalogX and AnalogY are analog stick axes (from -1 to 1, with +Y being down) used for directional input,
+	// along with the Left / Right / Up / Down inputs. The stick's direction is snapped to 4 (or 8, with AnalogEightWay)
+	// directions, and the further the stick is pushed, the faster the input repeats.
+	AnalogX, AnalogY float32
+
+	// AnalogDeadZone is how far the analog stick needs to be pushed (from 0 to 1) to register as directional input. Defaults to 0.25.
+	AnalogDeadZone float32
+
+	// AnalogEightWay snaps the analog stick to 8 directions rather than 4, allowing diagonal navigation if the
+	// NavigationStrategy in use supports it (see DiagonalNavigationStrategy).
+	AnalogEightWay bool
+
+	// AnalogMaxRepeatSpeed is how many times faster directional input repeats with the analog stick pushed all
+	// the way, compared to just past the dead zone. Defaults to 4.
+	AnalogMaxRepeatSpeed float32
+
 	UseMouse       bool // Whether or not to use the mouse for selecting and clicking UI elements
 	LeftMouseClick bool // The input to use for clicking (for rebinding)
+	UseTouch       bool // Whether or not to use touch input (read from the Context's InputProvider) for pressing, dragging, and scrolling
 
 	NoRememberHighlighting bool
 
@@ -154,164 +283,217 @@ type UpdateSettings struct {
 
 	// HighlightControlRepeatDelay is how frequently holding a highlight control input repeats after the initial delay.
 	HighlightControlRepeatDelay time.Duration
-}
-
-var queuedInput = queuedInputNone
-var prevQueuedInput = queuedInputNone
-var repeatingMouseClick = false
-var justClicked = false
-var prevMouseClick = false
-var usingMouse = true
-var canMoveHighlight = true
-
-var highlightedElement *uiElementInstance
-var highlightControlInitialTime time.Time
-var highlightControlStartTime time.Time
-var updateSettings UpdateSettings
 
-// var repeatTimer time.Time
-
-// var inputChars []rune
-// var regexString string
-// var caretPos int
-var targetText *[]rune
-
-var begun = false
+	// DeltaTime is how much time has passed since the previous frame. When set, gooey runs in a tick-based mode:
+	// the Context's Clock is ignored, the frame time advances by DeltaTime for input repeat delays, and per-frame
+	// values (like Layout.AutoScrollSpeed and UISlider.SliderHeadLerpPercentage) are scaled as if they were
+	// specified for a 60 FPS frame. When unset (0), the Context's Clock is used and per-frame values are applied once each frame.
+	DeltaTime time.Duration
+}
 
-// Begin ends the frame the updates input-related things from gooey.
-func Begin(settings UpdateSettings) error {
+// Context holds the state for an independent UI - its screen buffer, Layouts, highlighting, and input state.
+// The package-level functions (Init, Begin, End, Texture, NewLayout, etc.) operate on a default Context;
+// create additional Contexts with NewContext() to run several UIs at once (e.g. a pause menu and an in-world terminal).
+type Context struct {
+
+	// EvictAfterFrames is how many frames a UI element instance (or Layout) can go without being drawn before it's
+	// automatically removed, discarding its state. This is useful when drawing elements with dynamic IDs (e.g. one per
+	// inventory item), which would otherwise accumulate forever. If <= 0 (the default), instances are never evicted automatically.
+	EvictAfterFrames int
+
+	// OnEvicted, if set, is called for each UI element instance that is removed, whether automatically (see
+	// EvictAfterFrames) or explicitly (Layout.Remove(), Context.DestroyLayout()), with a reference to the UI element
+	// and its state. This can be used to persist widget state before it's dropped; as UI elements in different ID
+	// scopes can share an ID, use the ElementRef's Hash (or compare it to Layout.Ref()) to tell them apart.
+	OnEvicted func(element ElementRef, state any)
+
+	// NavigationStrategy determines which UI element is highlighted next when directional or next / previous input is
+	// pressed, unless the highlighted element's Layout has its own NavigationStrategy set. If nil (the default),
+	// a ConeNavigationStrategy with default settings is used. Set it to LegacyNavigationStrategy{} to use gooey's
+	// original navigation behavior, which wraps around at the edges (see NavigationStrategy).
+	NavigationStrategy NavigationStrategy
+
+	// OnHighlightChanged, if set, is called at the end of each frame in which the highlighted UI element changed,
+	// with the previously and newly highlighted UI elements (either of which can be zero if nothing was or is highlighted).
+	OnHighlightChanged func(from, to ElementRef)
+
+	// OnPressed, if set, is called when a UI element (a UIButton, or a Widget that calls DrawCall.NotifyPressed()) is pressed.
+	OnPressed func(element ElementRef)
+
+	// OnCancel, if set, is called at the end of a frame in which cancel input was pressed without being consumed by a
+	// UI element, with the highlighted UI element (which can be zero if nothing is highlighted).
+	OnCancel func(highlighted ElementRef)
+
+	// PlayerHighlightColors, if set, are the colors UI elements are drawn with when highlighted by each player (indexed
+	// by player ID; see BeginPlayers()), in place of the UI elements' own highlight colors. This makes it clear which
+	// player is highlighting what when several players navigate the same Layouts.
+	PlayerHighlightColors []Color
+
+	screenBuffer  *ebiten.Image
+	inputProvider InputProvider
+	frameInput    InputProvider // The InputProvider used for the current frame
+	cursor        Vector2
+	frameTime     time.Time
+	clock         Clock
+	actionMap     *ActionMap
+
+	recording *InputRecording
+	replay    *inputReplay
+
+	presentationGeoM     ebiten.GeoM
+	presentationInverse  ebiten.GeoM
+	presentationViewport *Rect       // Set by SetPresentationViewport(); the presentation GeoM is computed from it
+	presentationSize     image.Point // The screen buffer size the presentation GeoM was last computed for
+
+	visibleLayouts     []*Layout
+	existingLayouts    []*Layout
+	layoutsFromStrings map[string]map[rune]*Layout
+	drawFrame          uint64 // Incremented each Begin(); UI element instances are stamped with it when drawn
+
+	*playerState      // The state of the active player; see BeginPlayers()
+	players           []*playerState
+	lastPressedPlayer int
+
+	navigationStack []*NavigationEntry
+
+	touches      []*touchPoint
+	touchIDs     []ebiten.TouchID
+	touchStarted bool        // If a touch started this frame
+	touchEnabled bool        // If any player is using touch input
+	mouseDrag    *touchPoint // The mouse being dragged with the primary player's click input held, if it is
+
+	// repeatTimer time.Time
+
+	// inputChars []rune
+	// regexString string
+	// caretPos int
+	targetText *[]rune
+
+	begun bool
+
+	rememberFrame uint32
+
+	events []Event
+}
 
-	if begun {
-		return errors.New("error: gooey.Begin() called without a previously corresponding gooey.End() call")
+// NewContext creates a new, independent Context. Call Context.Init() to create its screen buffer before use.
+func NewContext() *Context {
+	primary := newPlayerState(0)
+	return &Context{
+		layoutsFromStrings: map[string]map[rune]*Layout{},
+		playerState:        primary,
+		players:            []*playerState{primary},
+		lastPressedPlayer:  -1,
+		inputProvider:      EbitenInputProvider{},
+		frameInput:         EbitenInputProvider{},
+		clock:              SystemClock{},
+		drawFrame:          1, // Start at 1 so new (zero-stamped) instances are never mistaken for already-drawn ones
 	}
+}
 
-	begun = true
-
-	queuedInput = queuedInputNone
-
-	// Do this here
-
-	if targetText == nil {
-
-		if settings.RightInput {
-			queuedInput = queuedInputRight
-		}
+var defaultContext = NewContext()
 
-		if settings.LeftInput {
-			queuedInput = queuedInputLeft
-		}
-		if settings.UpInput {
-			queuedInput = queuedInputUp
-		}
-		if settings.DownInput {
-			queuedInput = queuedInputDown
-		}
+// DefaultContext returns the default Context used by the package-level functions.
+func DefaultContext() *Context {
+	return defaultContext
+}
 
-		if settings.NextInput {
-			queuedInput = queuedInputNext
-		}
-		if settings.PrevInput {
-			queuedInput = queuedInputPrev
-		}
+// Begin ends the frame the updates input-related things from gooey for the default Context.
+func Begin(settings UpdateSettings) error {
+	return defaultContext.Begin(settings)
+}
 
-		if settings.AcceptInput {
-			queuedInput = queuedInputSelect
-		}
-		if settings.CancelInput {
-			queuedInput = queuedInputCancel
-		}
+// Begin ends the frame the updates input-related things from gooey for the Context.
+func (c *Context) Begin(settings UpdateSettings) error {
+	return c.BeginPlayers(settings)
+}
 
-		// if settings.CancelInput && !focusedUIElement {
-		// 	if opt, exists := Highlights[highlightingUIID]; exists && opt.OnCancel != nil {
-		// 		opt.OnCancel()
-		// 	}
-		// }
+// BeginPlayers is like Begin(), but for several players navigating the default Context's UI at once. See Context.BeginPlayers().
+func BeginPlayers(settings ...UpdateSettings) error {
+	return defaultContext.BeginPlayers(settings...)
+}
 
+// BeginPlayers is like Begin(), but for several players navigating the Context's UI at once (e.g. on a co-op character
+// select screen). Each UpdateSettings passed is the input for the player with that index as its ID; each player has
+// their own highlighted UI element, remember cache, and input repeat timers. Player 0 is the primary player; the
+// package-level and Context functions that deal with highlighting (Highlight(), HighlightedUIElement(), PushNavigation(), etc.)
+// act on the primary player unless called inside AsPlayer() (or from a callback or UI element drawn for another player).
+//
+// Some input is shared rather than per-player. The frame time is shared, so only player 0's DeltaTime is used. The
+// Context's ActionMap (see SetActionMap()) only drives player 0's input, so other players' directional, accept, and
+// cancel input needs to be read by your game (e.g. from their gamepads) and passed in their UpdateSettings; the
+// ActionMap's RepeatPolicies do apply to every player, though. There's also only one mouse cursor and set of touches,
+// so only one player (usually player 0) should set UseMouse or UseTouch.
+//
+// Passing fewer UpdateSettings than the previous frame removes the extra players.
+//
+//	ctx.BeginPlayers(
+//		gooey.UpdateSettings{UseMouse: true, LeftMouseClick: ..., LeftInput: ...}, // Player 0
+//		gooey.UpdateSettings{LeftInput: ..., RightInput: ..., AcceptInput: ...},   // Player 1
+//	)
+func (c *Context) BeginPlayers(settings ...UpdateSettings) error {
+
+	if c.begun {
+		return errors.New("error: gooey.Begin() called without a previously corresponding gooey.End() call")
 	}
 
-	updateSettings = settings
-
-	if !settings.UseMouse {
-		usingMouse = false
+	if len(settings) == 0 {
+		settings = []UpdateSettings{{}}
 	} else {
-		repeatingMouseClick = settings.LeftMouseClick
-		if repeatingMouseClick {
-			usingMouse = true
-			highlightedElement = nil
-		}
+		settings = slices.Clone(settings)
 	}
 
-	if queuedInput != queuedInputNone {
-		usingMouse = false
-	}
+	c.begun = true
 
-	if settings.HighlightControlRepeatDelay == 0 {
-		settings.HighlightControlRepeatDelay = time.Second / 8
-	}
+	c.frameInput = c.inputProvider
 
-	if settings.HighlightControlRepeatInitialDelay == 0 {
-		settings.HighlightControlRepeatInitialDelay = time.Second / 4
+	if settings[0].DeltaTime > 0 {
+		c.frameTime = c.frameTime.Add(settings[0].DeltaTime)
+	} else {
+		c.frameTime = c.clock.Now()
 	}
 
-	if queuedInput != queuedInputNone {
-
-		if queuedInput != prevQueuedInput {
-
-			highlightControlStartTime = time.Now()
-			highlightControlInitialTime = time.Now()
-
-			prevQueuedInput = queuedInput
-
+	if c.replay != nil {
+		if frame, ok := c.replay.next(); ok {
+			settings = append([]UpdateSettings{frame.Settings}, frame.Players...)
+			c.frameTime = c.replay.start.Add(frame.Time)
+			c.frameInput = c.replay
 		} else {
-
-			if time.Since(highlightControlInitialTime) < settings.HighlightControlRepeatInitialDelay {
-				prevQueuedInput = queuedInput
-				queuedInput = queuedInputNone
-			} else if time.Since(highlightControlStartTime) < settings.HighlightControlRepeatDelay {
-				prevQueuedInput = queuedInput
-				queuedInput = queuedInputNone
-			} else {
-				highlightControlStartTime = time.Now() // Let through one input
-			}
-
+			c.replay = nil
 		}
-
-	} else {
-		prevQueuedInput = queuedInputNone
-		queuedInput = queuedInputNone
 	}
 
-	justClicked = false
-
-	if usingMouse {
-
-		if settings.LeftMouseClick {
+	// While replaying, the ActionMap's Actions are driven by the recorded frame.
+	if c.actionMap != nil {
+		settings[0] = c.actionMap.update(c, settings[0])
+	}
 
-			if repeatingMouseClick != prevMouseClick {
+	// The frame time is shared between players, so they all use the primary player's DeltaTime.
+	for i := range settings {
+		settings[i].DeltaTime = settings[0].DeltaTime
+	}
 
-				highlightControlStartTime = time.Now()
-				highlightControlInitialTime = time.Now()
-				justClicked = true
-				prevMouseClick = repeatingMouseClick
+	if c.recording != nil {
+		c.recording.record(c.frameTime, settings, c.frameInput, c.actionMap)
+	}
 
-			} else {
+	c.cursor = c.ScreenToBuffer(c.frameInput.CursorPosition())
 
-				if time.Since(highlightControlInitialTime) < settings.HighlightControlRepeatInitialDelay || time.Since(highlightControlStartTime) < settings.HighlightControlRepeatDelay {
-					prevMouseClick = repeatingMouseClick
-					repeatingMouseClick = false
-				} else {
-					highlightControlStartTime = time.Now() // Let through one input
-				}
+	c.events = c.events[:0]
+	c.lastPressedPlayer = -1
 
-			}
+	c.updateTouches()
+	c.touchEnabled = slices.ContainsFunc(settings, func(s UpdateSettings) bool { return s.UseTouch })
 
-		} else {
-			repeatingMouseClick = false
-			prevMouseClick = false
-		}
+	c.setPlayerCount(len(settings))
 
+	for i, s := range settings {
+		c.playerState = c.players[i]
+		c.updatePlayerInput(s)
 	}
 
+	c.playerState = c.players[0]
+
 	// if targetText != nil {
 
 	// 	text := *targetText
@@ -410,148 +592,209 @@ func Begin(settings UpdateSettings) error {
 
 	// focusedUIElement = false
 
-	if highlightedElement != nil {
+	for _, layout := range c.visibleLayouts {
+		layout.committedMaxRect = layout.currentMaxRect.MoveVec(layout.Offset.Invert())
+		layout.contentRect = layout.committedMaxRect
+		layout.currentMaxRect = Rect{}
+	}
 
-		for _, layout := range visibleLayouts {
+	c.updateScrolling()
 
-			layout.committedMaxRect = layout.currentMaxRect.MoveVec(layout.Offset.Invert())
-			layout.currentMaxRect = Rect{}
+	delta := c.deltaScale()
 
-			thisLayoutHasHighlightedElement := false
+	for _, layout := range c.visibleLayouts {
 
-			for _, e := range layout.existingUIElements.Data {
-				if e == highlightedElement {
-					thisLayoutHasHighlightedElement = true
-					break
+		if layout.AutoScrollSpeed <= 0 {
+			continue
+		}
+
+		// Scroll to the highlighted UI element of the first player (in order) that has one in the Layout, unless the
+		// Layout's been scrolled with the mouse wheel or by dragging since that player last pressed a navigation input.
+		for _, p := range c.players {
+			if e := p.highlightedElement; e != nil && layout.existingUIElements.Data[e.hash] == e {
+				if p.queuedInput != queuedInputNone {
+					layout.userScrolled = false
 				}
+				if !layout.userScrolled {
+					layout.autoScrollTo(e, delta)
+				}
+				break
 			}
+		}
 
-			if thisLayoutHasHighlightedElement && layout.AutoScrollSpeed > 0 {
+	}
 
-				scrollingX := false
-				scrollingY := false
+	// Reset visible layouts at the end of Begin so we have layouts / drawn UI elements to work
+	// with for highlight movement
+	c.visibleLayouts = c.visibleLayouts[:0]
+	c.drawFrame++
 
-				if layout.committedMaxRect.H > layout.Rect.H {
-					scrollingY = true
+	c.screenBuffer.Clear()
 
-					centerScreenY := layout.Rect.Y + (layout.Rect.H / 2)
-					edgeSlop := layout.Rect.H / 3
+	for _, layout := range c.existingLayouts {
+		for _, inst := range layout.existingUIElements.Data {
+			inst.wasDrawn = false
+		}
+	}
 
-					// Basically, if the element is small enough, then scroll the screen to put it wholly onscreen
-					// with some extra tolerance (i.e. some distance away from the edge)
-					downTooFar := highlightedElement.currentRect.Bottom() > centerScreenY+edgeSlop
-					upTooFar := highlightedElement.currentRect.Y < centerScreenY-edgeSlop
+	c.evictStale()
 
-					// If it's too big, then we just scroll it so its leading edge is onscreen
-					if highlightedElement.currentRect.H > edgeSlop {
-						downTooFar = highlightedElement.currentRect.Bottom() > layout.Rect.Y+layout.Rect.H
-						upTooFar = highlightedElement.currentRect.Y < layout.Rect.Y
-					}
+	return nil
+}
 
-					if downTooFar {
-						layout.autoScrollCurrentSpeed.Y -= layout.AutoScrollAcceleration
-					} else if upTooFar {
-						layout.autoScrollCurrentSpeed.Y += layout.AutoScrollAcceleration
-					} else {
+// updatePlayerInput updates the active player's queued input, input repeat timers, and mouse state from the given UpdateSettings.
+func (c *Context) updatePlayerInput(settings UpdateSettings) {
 
-						if layout.autoScrollCurrentSpeed.Y >= layout.AutoScrollAcceleration {
-							layout.autoScrollCurrentSpeed.Y -= layout.AutoScrollAcceleration
-						} else if layout.autoScrollCurrentSpeed.Y <= -layout.AutoScrollAcceleration {
-							layout.autoScrollCurrentSpeed.Y += layout.AutoScrollAcceleration
-						} else {
-							layout.autoScrollCurrentSpeed.Y = 0
-						}
+	c.lastPressed = nil
 
-					}
+	c.queuedInput = queuedInputNone
+	c.analogDirection = Vector2{}
+	analogTilt := float32(0)
 
-				} else {
-					layout.Offset.Y = 0
-				}
+	// Do this here
 
-				if layout.committedMaxRect.W > layout.Rect.W {
-					scrollingX = true
+	if c.targetText == nil {
 
-					centerScreenX := layout.Rect.X + (layout.Rect.W / 2)
-					edgeSlop := layout.Rect.W / 3
+		if settings.RightInput {
+			c.queuedInput = queuedInputRight
+		}
 
-					// Basically, if the element is small enough, then scroll the screen to put it wholly onscreen
-					// with some extra tolerance (i.e. some distance away from the edge)
-					rightTooFar := highlightedElement.currentRect.Right() > centerScreenX+edgeSlop
-					leftTooFar := highlightedElement.currentRect.X < centerScreenX-edgeSlop
+		if settings.LeftInput {
+			c.queuedInput = queuedInputLeft
+		}
+		if settings.UpInput {
+			c.queuedInput = queuedInputUp
+		}
+		if settings.DownInput {
+			c.queuedInput = queuedInputDown
+		}
 
-					// If it's too big, then we just scroll it so its leading edge is onscreen
-					if highlightedElement.currentRect.W >= edgeSlop {
-						rightTooFar = highlightedElement.currentRect.Right() > layout.Rect.X+layout.Rect.W
-						leftTooFar = highlightedElement.currentRect.X < layout.Rect.X
-					}
+		if settings.NextInput {
+			c.queuedInput = queuedInputNext
+		}
+		if settings.PrevInput {
+			c.queuedInput = queuedInputPrev
+		}
 
-					if rightTooFar {
-						layout.autoScrollCurrentSpeed.X -= layout.AutoScrollAcceleration
-					} else if leftTooFar {
-						layout.autoScrollCurrentSpeed.X += layout.AutoScrollAcceleration
-					} else {
+		if c.queuedInput == queuedInputNone {
+			c.queuedInput, c.analogDirection, analogTilt = settings.analogInput()
+			if !settings.AnalogEightWay || c.analogDirection.X == 0 || c.analogDirection.Y == 0 {
+				c.analogDirection = Vector2{}
+			}
+		}
 
-						if layout.autoScrollCurrentSpeed.X >= layout.AutoScrollAcceleration {
-							layout.autoScrollCurrentSpeed.X -= layout.AutoScrollAcceleration
-						} else if layout.autoScrollCurrentSpeed.X <= -layout.AutoScrollAcceleration {
-							layout.autoScrollCurrentSpeed.X += layout.AutoScrollAcceleration
-						} else {
-							layout.autoScrollCurrentSpeed.X = 0
-						}
+		if settings.AcceptInput {
+			c.queuedInput = queuedInputSelect
+		}
+		if settings.CancelInput {
+			c.queuedInput = queuedInputCancel
+		}
 
-					}
+	}
 
-				} else {
-					layout.Offset.X = 0
-				}
+	c.updateSettings = settings
 
-				if scrollingY {
+	if !settings.UseMouse {
+		c.usingMouse = false
+	} else {
+		c.repeatingMouseClick = settings.LeftMouseClick
+		if c.repeatingMouseClick {
+			c.usingMouse = true
+			c.usingTouch = false
+			c.highlightedElement = nil
+		}
+	}
 
-					layout.autoScrollCurrentSpeed.Y = clamp(layout.autoScrollCurrentSpeed.Y, -layout.AutoScrollSpeed, layout.AutoScrollSpeed)
+	if !settings.UseTouch {
+		c.usingTouch = false
+	} else if c.touchStarted {
+		c.usingTouch = true
+		c.usingMouse = false
+		c.highlightedElement = nil
+	}
 
-					ogScrollY := layout.Offset.Y
-					layout.Offset.Y = clamp(layout.Offset.Y+layout.autoScrollCurrentSpeed.Y, -(layout.committedMaxRect.H - layout.Rect.H), 0)
+	if c.queuedInput != queuedInputNone {
+		c.usingMouse = false
+		c.usingTouch = false
+	}
 
-					// Scroll's the same as clamped; it hit a barrier, stop speed
-					if layout.Offset.Y == ogScrollY {
-						layout.autoScrollCurrentSpeed.Y = 0
-					}
-				}
+	if settings.HighlightControlRepeatDelay == 0 {
+		settings.HighlightControlRepeatDelay = time.Second / 8
+	}
+
+	if settings.HighlightControlRepeatInitialDelay == 0 {
+		settings.HighlightControlRepeatInitialDelay = time.Second / 4
+	}
 
-				if scrollingX {
+	if c.queuedInput != queuedInputNone {
 
-					layout.autoScrollCurrentSpeed.X = clamp(layout.autoScrollCurrentSpeed.X, -layout.AutoScrollSpeed, layout.AutoScrollSpeed)
+		initialDelay, repeatDelay, repeat := c.repeatPolicy(queuedInputAction(c.queuedInput)).delays(settings, c.highlightRepeatCount)
 
-					ogScrollX := layout.Offset.X
-					layout.Offset.X = clamp(layout.Offset.X+layout.autoScrollCurrentSpeed.X, -(layout.committedMaxRect.W - layout.Rect.W), 0)
+		if analogTilt > 0 {
+			repeatDelay = time.Duration(float32(repeatDelay) / settings.analogRepeatSpeed(analogTilt))
+		}
 
-					// Scroll's the same as clamped; it hit a barrier, stop speed
-					if layout.Offset.X == ogScrollX {
-						layout.autoScrollCurrentSpeed.X = 0
-					}
+		if c.queuedInput != c.prevQueuedInput {
 
-				}
+			c.highlightControlStartTime = c.frameTime
+			c.highlightControlInitialTime = c.frameTime
+			c.highlightRepeatCount = 0
 
+			c.prevQueuedInput = c.queuedInput
+
+		} else {
+
+			if !repeat || c.frameTime.Sub(c.highlightControlInitialTime) < initialDelay {
+				c.prevQueuedInput = c.queuedInput
+				c.queuedInput = queuedInputNone
+			} else if c.frameTime.Sub(c.highlightControlStartTime) < repeatDelay {
+				c.prevQueuedInput = c.queuedInput
+				c.queuedInput = queuedInputNone
+			} else {
+				c.highlightControlStartTime = c.frameTime // Let through one input
+				c.highlightRepeatCount++
 			}
 
 		}
+
+	} else {
+		c.prevQueuedInput = queuedInputNone
+		c.queuedInput = queuedInputNone
 	}
 
-	// Reset visible layouts at the end of Begin so we have layouts / drawn UI elements to work
-	// with for highlight movement
-	visibleLayouts = visibleLayouts[:0]
-	clear(IDsInUse)
+	c.justClicked = false
 
-	screenBuffer.Clear()
-	prevMouseClick = updateSettings.LeftMouseClick
+	if c.usingMouse {
 
-	for _, layout := range existingLayouts {
-		for _, inst := range layout.existingUIElements.Data {
-			inst.wasDrawn = false
+		if settings.LeftMouseClick {
+
+			if c.repeatingMouseClick != c.prevMouseClick {
+
+				c.highlightControlStartTime = c.frameTime
+				c.highlightControlInitialTime = c.frameTime
+				c.justClicked = true
+				c.prevMouseClick = c.repeatingMouseClick
+
+			} else {
+
+				if c.frameTime.Sub(c.highlightControlInitialTime) < settings.HighlightControlRepeatInitialDelay || c.frameTime.Sub(c.highlightControlStartTime) < settings.HighlightControlRepeatDelay {
+					c.prevMouseClick = c.repeatingMouseClick
+					c.repeatingMouseClick = false
+				} else {
+					c.highlightControlStartTime = c.frameTime // Let through one input
+				}
+
+			}
+
+		} else {
+			c.repeatingMouseClick = false
+			c.prevMouseClick = false
 		}
+
 	}
 
-	return nil
+	c.prevMouseClick = c.updateSettings.LeftMouseClick
+
 }
 
 type rememberEntry struct {
@@ -559,51 +802,128 @@ type rememberEntry struct {
 	Time     uint32
 }
 
-var rememberFrame = uint32(0)
-var rememberCache = []rememberEntry{}
+// remember records the given UI element instance as the one most recently highlighted by the player.
+func (p *playerState) remember(inst *uiElementInstance, time uint32) {
+
+	for i := range p.rememberCache {
+		if p.rememberCache[i].Instance == inst {
+			p.rememberCache[i].Time = time
+			return
+		}
+	}
+
+	p.rememberCache = append(p.rememberCache, rememberEntry{
+		Instance: inst,
+		Time:     time,
+	})
+
+}
+
+// remembered returns the drawn, highlightable UI element instance that the player most recently highlighted in the
+// given Layout, or nil if there's none.
+func (p *playerState) remembered(l *Layout) *uiElementInstance {
+
+	var found *rememberEntry
+
+	for i, n := range p.rememberCache {
+		if n.Instance.layout == l && n.Instance.wasDrawn && n.Instance.drawable.highlightable() && (found == nil || n.Time > found.Time) {
+			found = &p.rememberCache[i]
+		}
+	}
+
+	if found == nil {
+		return nil
+	}
+
+	return found.Instance
+
+}
 
+// ClearRememberCache clears the default Context's cache of previously highlighted UI elements.
 func ClearRememberCache() {
-	rememberCache = rememberCache[:0]
+	defaultContext.ClearRememberCache()
+}
+
+// ClearRememberCache clears the Context's cache of previously highlighted UI elements.
+func (c *Context) ClearRememberCache() {
+	c.rememberCache = c.rememberCache[:0]
 }
 
+// End finishes the frame for the default Context, moving the highlight as necessary.
 func End() {
+	defaultContext.End()
+}
+
+// End finishes the frame for the Context, moving the highlight as necessary.
+func (c *Context) End() {
+
+	c.begun = false
+
+	for _, p := range c.players {
+		c.playerState = p
+		c.updatePlayerHighlight()
+	}
 
-	begun = false
+	c.playerState = c.players[0]
 
-	if (highlightedElement == nil || !highlightedElement.layout.isVisible() || !highlightedElement.wasDrawn || highlightedElement.layout.HighlightingLocked) && (queuedInput != 0 || !updateSettings.NoDefaultHighlightOption) && !usingMouse {
+	c.rememberFrame++
 
-		highlightedElement = nil
+}
+
+// updatePlayerHighlight moves the active player's highlight according to their input, or highlights a default UI
+// element if necessary.
+func (c *Context) updatePlayerHighlight() {
+
+	c.resolvePendingHighlight()
 
-		if !updateSettings.NoRememberHighlighting {
-			sort.Slice(rememberCache, func(i, j int) bool {
-				return rememberCache[i].Time > rememberCache[j].Time
+	if c.queuedInput == queuedInputCancel {
+		c.cancelled()
+	}
+
+	if c.queuedInput != queuedInputNone {
+		c.highlightCleared = false
+	}
+
+	if (c.highlightedElement == nil || !c.highlightedElement.layout.isVisible() || !c.highlightedElement.wasDrawn || c.highlightingLocked(c.highlightedElement.layout)) && (c.queuedInput != 0 || (!c.updateSettings.NoDefaultHighlightOption && !c.highlightCleared)) && !c.usingMouse && !c.usingTouch {
+
+		c.highlightedElement = nil
+
+		if !c.updateSettings.NoRememberHighlighting {
+			sort.Slice(c.rememberCache, func(i, j int) bool {
+				return c.rememberCache[i].Time > c.rememberCache[j].Time
 			})
 
-			for _, n := range rememberCache {
+			for _, n := range c.rememberCache {
 
-				if n.Instance.wasDrawn && n.Instance.layout.isVisible() && !n.Instance.layout.HighlightingLocked {
-					highlightedElement = n.Instance
+				if n.Instance.wasDrawn && n.Instance.layout.isVisible() && !c.highlightingLocked(n.Instance.layout) {
+					c.highlightedElement = n.Instance
 					break
 				}
 			}
 
 		}
 
-		if highlightedElement == nil {
+		if c.highlightedElement == nil {
 
-			for _, layout := range visibleLayouts {
+			for _, layout := range c.visibleLayouts {
 
 				found := false
 
-				if layout.HighlightingLocked {
+				if c.highlightingLocked(layout) {
 					continue
 				}
 
-				if len(layout.CustomHighlightingOrder) > 0 {
+				// Elements highlighted before were already considered above, so go with the Layout's default, if it's set.
+				if element := layout.defaultHighlight(); element != nil {
+					c.highlightedElement = element
+					found = true
+				}
+
+				if !found && len(layout.CustomHighlightingOrder) > 0 {
 					for _, e := range layout.CustomHighlightingOrder {
-						element := layout.existingUIElements.Data[e]
+						element := layout.existingUIElements.Get(e)
 						if element != nil && element.drawable.highlightable() && element.wasDrawn {
-							highlightedElement = element
+							c.highlightedElement = element
 							found = true
 						}
 					}
@@ -614,7 +934,7 @@ func End() {
 					layout.existingUIElements.ForEach(func(element *uiElementInstance) bool {
 
 						if element.drawable.highlightable() && element.wasDrawn {
-							highlightedElement = element
+							c.highlightedElement = element
 							found = true
 							return false
 						}
@@ -624,10 +944,6 @@ func End() {
 
 				}
 
-				// Implement remember highlighting here
-				// To do this, we need to keep track of the last highlighted element
-				// for each layout, and when looking for a new element to highlight,
-				// search through the layouts to determine if any of them were last highlighted
 				if found {
 					break
 				}
@@ -635,15 +951,13 @@ func End() {
 
 		}
 
-	} else if highlightedElement != nil && queuedInput != queuedInputSelect {
-
-		currentElementRect := highlightedElement.currentRect
+	} else if c.highlightedElement != nil && c.queuedInput != queuedInputSelect && c.editingElement != c.highlightedElement {
 
 		visibleHighlightableElements := []*uiElementInstance{}
 
-		for _, layout := range visibleLayouts {
+		for _, layout := range c.visibleLayouts {
 
-			if layout.HighlightingLocked {
+			if c.highlightingLocked(layout) {
 				continue
 			}
 
@@ -658,44 +972,23 @@ func End() {
 
 		}
 
-		sortByDistance := func(targetPos Vector2) {
-
-			sort.Slice(visibleHighlightableElements, func(i, j int) bool {
-				ir := visibleHighlightableElements[i].currentRect
-				jr := visibleHighlightableElements[j].currentRect
-				return ir.Center().DistanceTo(targetPos) < jr.Center().DistanceTo(targetPos)
-			})
-
-		}
-
-		sortByXY := func() {
-
-			sort.Slice(visibleHighlightableElements, func(i, j int) bool {
-				ir := visibleHighlightableElements[i].currentRect
-				jr := visibleHighlightableElements[j].currentRect
-				return ir.X+(ir.Y*100000) < jr.X+(jr.Y*100000)
-				// return ir.Center().X+(ir.Center().Y*100000) < jr.Center().X+(jr.Center().Y*100000)
-			})
-
-		}
-
-		layout := highlightedElement.layout
+		layout := c.highlightedElement.layout
 
-		elementFound := false
+		elementFound := c.navigateToNeighbor(NavigationInput(c.queuedInput))
 
-		if len(layout.CustomHighlightingOrder) > 0 {
+		if !elementFound && len(layout.CustomHighlightingOrder) > 0 {
 
 			targetID := ""
 
 			for i, e := range layout.CustomHighlightingOrder {
-				if e == highlightedElement.id {
-					if queuedInput == queuedInputRight || queuedInput == queuedInputDown || queuedInput == queuedInputNext {
+				if hashIDString(idHashSeed, e) == c.highlightedElement.hash {
+					if c.queuedInput == queuedInputRight || c.queuedInput == queuedInputDown || c.queuedInput == queuedInputNext {
 						if i < len(layout.CustomHighlightingOrder)-1 {
 							targetID = layout.CustomHighlightingOrder[i+1]
 						} else {
 							targetID = layout.CustomHighlightingOrder[0]
 						}
-					} else if queuedInput == queuedInputLeft || queuedInput == queuedInputUp || queuedInput == queuedInputPrev {
+					} else if c.queuedInput == queuedInputLeft || c.queuedInput == queuedInputUp || c.queuedInput == queuedInputPrev {
 						if i > 0 {
 							targetID = layout.CustomHighlightingOrder[i-1]
 						} else {
@@ -707,9 +1000,10 @@ func End() {
 			}
 
 			if targetID != "" {
+				targetHash := hashIDString(idHashSeed, targetID)
 				for _, e := range visibleHighlightableElements {
-					if e.id == targetID {
-						highlightedElement = e
+					if e.hash == targetHash {
+						c.highlightedElement = e
 						elementFound = true
 						break
 					}
@@ -719,214 +1013,95 @@ func End() {
 		}
 
 		if !elementFound {
-
-			var closest *uiElementInstance
-
-			switch queuedInput {
-			case queuedInputRight:
-				next := currentElementRect.Center()
-				next.X = currentElementRect.Right() + 1
-				sortByDistance(next)
-
-				for _, e := range visibleHighlightableElements {
-					if e == highlightedElement {
-						continue
-					}
-					if e.currentRect.Center().X > currentElementRect.Right() {
-						closest = e
-						break
-					}
-				}
-
-				// If there's nothing to the right, sort by distance to the negative location on the X axis and go for that one
-				if closest == nil {
-
-					for _, e := range visibleHighlightableElements {
-						if e == highlightedElement {
-							continue
-						}
-
-						overlap := e.currentRect.overlappingAxisY(currentElementRect)
-
-						if overlap > 0 && (closest == nil || e.currentRect.X < closest.currentRect.X) {
-							closest = e
-						}
-					}
-
-				}
-			case queuedInputLeft:
-
-				next := currentElementRect.Center()
-				next.X = currentElementRect.X - 1
-				sortByDistance(next)
-
-				for _, e := range visibleHighlightableElements {
-					if e == highlightedElement {
-						continue
-					}
-					if e.currentRect.Center().X < currentElementRect.X {
-						closest = e
-						break
-					}
-				}
-
-				if closest == nil {
-
-					for _, e := range visibleHighlightableElements {
-						if e == highlightedElement {
-							continue
-						}
-						if e.currentRect.overlappingAxisY(currentElementRect) > 0 && (closest == nil || e.currentRect.X > closest.currentRect.X) {
-							closest = e
-						}
-					}
-				}
-
-			case queuedInputUp:
-				next := currentElementRect.Center()
-				next.Y = currentElementRect.Y - 1
-				sortByDistance(next)
-				for _, e := range visibleHighlightableElements {
-					if e == highlightedElement {
-						continue
-					}
-					if e.currentRect.Center().Y < currentElementRect.Y {
-						closest = e
-						break
-					}
-				}
-
-				if closest == nil {
-
-					for _, e := range visibleHighlightableElements {
-						if e == highlightedElement {
-							continue
-						}
-						if e.currentRect.overlappingAxisX(currentElementRect) > 0 && (closest == nil || e.currentRect.Y > closest.currentRect.Y) {
-							closest = e
-						}
-					}
-				}
-
-			case queuedInputDown:
-				next := currentElementRect.Center()
-				next.Y = currentElementRect.Bottom() + 1
-				sortByDistance(next)
-				for _, e := range visibleHighlightableElements {
-					if e == highlightedElement {
-						continue
-					}
-					if e.currentRect.Center().Y > currentElementRect.Bottom() {
-						closest = e
-						break
-					}
-				}
-
-				if closest == nil {
-
-					for _, e := range visibleHighlightableElements {
-						if e == highlightedElement {
-							continue
-						}
-						if e.currentRect.overlappingAxisX(currentElementRect) > 0 && (closest == nil || e.currentRect.Y < closest.currentRect.Y) {
-							closest = e
-						}
-					}
-				}
-
-			case queuedInputNext:
-				sortByXY()
-				for index, e := range visibleHighlightableElements {
-					if e == highlightedElement {
-						if index < len(visibleHighlightableElements)-1 {
-							closest = visibleHighlightableElements[index+1]
-						} else {
-							closest = visibleHighlightableElements[0]
-						}
-					}
-				}
-
-			case queuedInputPrev:
-				sortByXY()
-				for index, e := range visibleHighlightableElements {
-					if e == highlightedElement {
-						if index > 0 {
-							closest = visibleHighlightableElements[index-1]
-						} else {
-							closest = visibleHighlightableElements[len(visibleHighlightableElements)-1]
-						}
-					}
-				}
-			}
-
-			if closest != nil {
-				highlightedElement = closest
-			}
-
+			c.navigate(visibleHighlightableElements)
 		}
 
 	}
 
-	if highlightedElement != nil {
-
-		found := false
-		for i := range rememberCache {
-			if rememberCache[i].Instance == highlightedElement {
-				rememberCache[i].Time = rememberFrame
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			rememberCache = append(rememberCache, rememberEntry{
-				Instance: highlightedElement,
-				Time:     rememberFrame,
-			})
-		}
+	if c.highlightedElement != nil {
+		c.remember(c.highlightedElement, c.rememberFrame)
+	}
 
-		// highlightedElement.layout.lastHighlightedElement = highlightedElement
-		// highlightedElement.layout.lastHighlightedTime = time.Now()
+	if c.editingElement != c.highlightedElement {
+		c.editingElement = nil
 	}
 
-	rememberFrame++
+	c.updateHighlightEvents()
 
 }
 
+// HighlightedUIElement returns the currently highlighted UI element instance in the default Context, or nil if nothing is highlighted.
 func HighlightedUIElement() *uiElementInstance {
-	return highlightedElement
+	return defaultContext.HighlightedUIElement()
+}
+
+// HighlightedUIElement returns the currently highlighted UI element instance in the Context, or nil if nothing is highlighted.
+func (c *Context) HighlightedUIElement() *uiElementInstance {
+	return c.highlightedElement
 }
 
 func InputPressedUp() bool {
-	return queuedInput == queuedInputUp
+	return defaultContext.InputPressedUp()
+}
+
+func (c *Context) InputPressedUp() bool {
+	return c.queuedInput == queuedInputUp
 }
 
 func InputPressedDown() bool {
-	return queuedInput == queuedInputDown
+	return defaultContext.InputPressedDown()
+}
+
+func (c *Context) InputPressedDown() bool {
+	return c.queuedInput == queuedInputDown
 }
 
 func InputPressedRight() bool {
-	return queuedInput == queuedInputRight
+	return defaultContext.InputPressedRight()
+}
+
+func (c *Context) InputPressedRight() bool {
+	return c.queuedInput == queuedInputRight
 }
 
 func InputPressedLeft() bool {
-	return queuedInput == queuedInputLeft
+	return defaultContext.InputPressedLeft()
+}
+
+func (c *Context) InputPressedLeft() bool {
+	return c.queuedInput == queuedInputLeft
 }
 
 func InputPressedSelect() bool {
-	return queuedInput == queuedInputSelect
+	return defaultContext.InputPressedSelect()
+}
+
+func (c *Context) InputPressedSelect() bool {
+	return c.queuedInput == queuedInputSelect
 }
 
 func InputPressedCancel() bool {
-	return queuedInput == queuedInputCancel
+	return defaultContext.InputPressedCancel()
+}
+
+func (c *Context) InputPressedCancel() bool {
+	return c.queuedInput == queuedInputCancel
 }
 
 func InputPressedNext() bool {
-	return queuedInput == queuedInputNext
+	return defaultContext.InputPressedNext()
+}
+
+func (c *Context) InputPressedNext() bool {
+	return c.queuedInput == queuedInputNext
 }
 
 func InputPressedPrev() bool {
-	return queuedInput == queuedInputPrev
+	return defaultContext.InputPressedPrev()
+}
+
+func (c *Context) InputPressedPrev() bool {
+	return c.queuedInput == queuedInputPrev
 }
 
 // func keyPressed(key ebiten.Key) bool {
@@ -959,26 +1134,21 @@ func InputPressedPrev() bool {
 // 	DirectionPrev
 // )
 
-// internalStateAccessOnce accesses a state associated with an ID.
-// If the state was accessed before in the current frame, then
-// this either panics or warns with a log print.
-func internalStateAccessOnce(id any) {
-
-	if _, ok := id.(UIElement); ok {
-		panic("gooey: id is a UIElement, which is likely not intentional")
-	}
-
-	for _, i := range IDsInUse {
-		if i == id {
-			switch UIIDReusePolicy {
-			case UIIDReusePolicyPanic:
-				panic(fmt.Sprint("gooey: UI element ID [", id, "] is used multiple times. Each UI element should have a unique ID."))
-			case UIIDReusePolicyWarn:
-				log.Println("gooey: UI element ID", id, "is used multiple times. Each UI element should have a unique ID.")
-			}
+// internalStateAccessOnce marks the UI element instance as drawn for the current frame.
+// If the instance was already drawn in the current frame (i.e. its ID was used multiple times
+// in the same Layout), then this either panics or warns with a log print, depending on UIIDReusePolicy.
+// Instances are stamped with the frame they were drawn in, so this check is O(1).
+func (c *Context) internalStateAccessOnce(inst *uiElementInstance) {
+
+	if inst.drawnFrame == c.drawFrame {
+		switch UIIDReusePolicy {
+		case UIIDReusePolicyPanic:
+			panic(fmt.Sprint("gooey: UI element ID [", inst.id, "] is used multiple times. Each UI element should have a unique ID."))
+		case UIIDReusePolicyWarn:
+			log.Println("gooey: UI element ID", inst.id, "is used multiple times. Each UI element should have a unique ID.")
 		}
 	}
 
-	IDsInUse = append(IDsInUse, id)
+	inst.drawnFrame = c.drawFrame
 
 }
@@ -0,0 +1,194 @@
+package gooey
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testWidget is a Widget that doesn't draw anything, for testing navigation and Layouts.
+type testWidget struct {
+	disabled bool
+}
+
+func (w testWidget) Highlightable() bool {
+	return !w.disabled
+}
+
+func (w testWidget) Draw(dc *DrawCall) {}
+
+// testFrameTime is how long each frame run by testFrame() takes; tests run in tick-based mode at 60 FPS.
+const testFrameTime = time.Second / 60
+
+// newTestContext returns a new Context with a 640x360 screen buffer.
+func newTestContext() *Context {
+	ctx := NewContext()
+	ctx.Init(640, 360)
+	return ctx
+}
+
+// testFrame runs a frame of the Context with the given input for each player, or with no input if none is given.
+// Inputs without a DeltaTime set advance by testFrameTime. draw is called between Begin() and End() to add Layouts and
+// UI elements, and can be nil.
+func testFrame(ctx *Context, draw func(), players ...UpdateSettings) {
+
+	players = append([]UpdateSettings{}, players...)
+
+	if len(players) == 0 {
+		players = append(players, UpdateSettings{})
+	}
+
+	for i := range players {
+		if players[i].DeltaTime == 0 {
+			players[i].DeltaTime = testFrameTime
+		}
+	}
+
+	if err := ctx.BeginPlayers(players...); err != nil {
+		panic(err)
+	}
+
+	if draw != nil {
+		draw()
+	}
+
+	ctx.End()
+
+}
+
+func BenchmarkLayoutAdd(b *testing.B) {
+
+	for _, count := range []int{100, 1000, 5000} {
+
+		b.Run(fmt.Sprint(count), func(b *testing.B) {
+
+			ctx := newTestContext()
+
+			ids := make([]string, count)
+			for i := range ids {
+				ids[i] = fmt.Sprint("item", i)
+			}
+
+			widget := NewUIWidget(testWidget{})
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+
+				testFrame(ctx, func() {
+
+					layout := ctx.NewLayout("inventory", 0, 0, 640, 360)
+					layout.SetArranger(ArrangerGrid{ElementCount: 10, ElementSize: Vector2{0, 32}})
+
+					for _, id := range ids {
+						widget.AddTo(layout, id)
+					}
+
+				})
+
+			}
+
+			// The cost per element should stay flat as the number of elements grows.
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*count), "ns/element")
+
+		})
+
+	}
+
+}
+
+func TestContextsAreIndependent(t *testing.T) {
+
+	pause := newTestContext()
+	terminal := newTestContext()
+
+	layouts := map[*Context]*Layout{}
+
+	// Both Contexts draw a Layout with the same ID and UI elements; only the terminal's player moves down.
+	for frame := 0; frame < 2; frame++ {
+
+		for _, ctx := range []*Context{pause, terminal} {
+
+			testFrame(ctx, func() {
+
+				menu := ctx.NewLayout("menu", 0, 0, 40, 80)
+				menu.SetArranger(ArrangerGrid{ElementCount: 1})
+				NewUIWidget(testWidget{}).AddTo(menu, "a")
+				NewUIWidget(testWidget{}).AddTo(menu, "b")
+				layouts[ctx] = menu
+
+				if frame == 0 {
+					ctx.Highlight(menu, "a")
+				}
+
+			}, UpdateSettings{DownInput: frame == 1 && ctx == terminal})
+
+		}
+
+	}
+
+	if h := pause.HighlightedUIElement(); h == nil || h.id != "a" {
+		t.Errorf("pause menu highlighted %v, want a", h.ref())
+	}
+
+	if h := terminal.HighlightedUIElement(); h == nil || h.id != "b" {
+		t.Errorf("terminal highlighted %v, want b", h.ref())
+	}
+
+	if layouts[pause] == layouts[terminal] {
+		t.Error("both Contexts share the same Layout")
+	}
+
+}
+
+func TestPresentationViewport(t *testing.T) {
+
+	ctx := NewContext()
+
+	// The 640x360 screen buffer is drawn letterboxed to the middle of a 1440x720 window, at twice its size.
+	ctx.SetPresentationViewport(Rect{80, 0, 1280, 720})
+	ctx.Init(640, 360)
+
+	if p := ctx.ScreenToBuffer(280, 200); p != (Vector2{100, 100}) {
+		t.Errorf("ScreenToBuffer() = %v, want {100, 100}", p)
+	}
+
+	if p := ctx.BufferToScreen(100, 100); p != (Vector2{280, 200}) {
+		t.Errorf("BufferToScreen() = %v, want {280, 200}", p)
+	}
+
+	// Hit tests use the cursor in buffer space, so clicking at the second button's position on the screen presses it.
+	provider := &testInputProvider{cursor: ctx.BufferToScreen(20, 60)}
+	ctx.SetInputProvider(provider)
+
+	pressed := ""
+
+	for frame := 0; frame < 3; frame++ {
+
+		testFrame(ctx, func() {
+
+			menu := ctx.NewLayout("menu", 0, 0, 40, 80)
+			menu.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+			for _, id := range []string{"a", "b"} {
+				if NewUIButton().AddTo(menu, id) {
+					pressed = id
+				}
+			}
+
+		}, UpdateSettings{UseMouse: true, LeftMouseClick: frame == 1})
+
+	}
+
+	if pressed != "b" {
+		t.Errorf("pressed %q, want b", pressed)
+	}
+
+	// The viewport is kept when the screen buffer is resized, so it's now drawn at four times its size.
+	ctx.Init(320, 180)
+
+	if p := ctx.ScreenToBuffer(480, 400); p != (Vector2{100, 100}) {
+		t.Errorf("ScreenToBuffer() = %v after resizing the screen buffer, want {100, 100}", p)
+	}
+
+}
@@ -0,0 +1,102 @@
+package gooey
+
+import "strconv"
+
+// UI element IDs are hashed to integers using 64-bit FNV-1a, combined with the hash of the ID scope they're used in
+// (see Layout.PushID()). This means that reusable components can be drawn many times with the same IDs in different
+// scopes, and child elements can be identified without building new strings every frame.
+const (
+	idHashSeed  uint64 = 14695981039346656037
+	idHashPrime uint64 = 1099511628211
+)
+
+func hashIDString(seed uint64, id string) uint64 {
+	return hashIDTerminate(hashIDBytes(seed, id))
+}
+
+// hashIDBytes hashes the given bytes into the hash, without terminating it (see hashIDTerminate()); this is used to
+// hash an ID in pieces.
+func hashIDBytes[S ~string | ~[]byte](h uint64, id S) uint64 {
+	for i := 0; i < len(id); i++ {
+		h ^= uint64(id[i])
+		h *= idHashPrime
+	}
+	return h
+}
+
+// hashIDTerminate mixes a terminator into the hash so that scopes "a" + "bc" and "ab" + "c" hash differently.
+func hashIDTerminate(h uint64) uint64 {
+	h ^= 0xff
+	h *= idHashPrime
+	return h
+}
+
+func hashIDInt(seed uint64, id int) uint64 {
+	h := seed
+	v := uint64(id)
+	for i := 0; i < 8; i++ {
+		h ^= v & 0xff
+		h *= idHashPrime
+		v >>= 8
+	}
+	return h
+}
+
+// idScope returns the hash of the Layout's current ID scope.
+func (l *Layout) idScope() uint64 {
+	if len(l.idStack) == 0 {
+		return idHashSeed
+	}
+	return l.idStack[len(l.idStack)-1]
+}
+
+// PushID pushes a new ID scope onto the Layout's ID stack. UI elements added to the Layout until the matching
+// PopID() call are identified by their ID combined with every ID on the stack, so IDs only need to be unique within
+// their scope. This is useful for reusable component functions, or for drawing elements in loops:
+//
+//	for i, item := range inventory {
+//		layout.PushIDInt(i)
+//		drawItemSlot(layout, item) // Can use the same IDs (e.g. "icon", "label") for each item
+//		layout.PopID()
+//	}
+//
+// The ID stack is cleared each frame when the Layout is retrieved with NewLayout().
+func (l *Layout) PushID(id string) {
+	l.idStack = append(l.idStack, hashIDString(l.idScope(), id))
+}
+
+// PushIDInt pushes a new ID scope onto the Layout's ID stack using an integer (e.g. a loop index) as the ID.
+// See PushID() for more information.
+func (l *Layout) PushIDInt(id int) {
+	l.idStack = append(l.idStack, hashIDInt(l.idScope(), id))
+}
+
+// PopID pops the most recently pushed ID scope off of the Layout's ID stack.
+func (l *Layout) PopID() {
+	if len(l.idStack) > 0 {
+		l.idStack = l.idStack[:len(l.idStack)-1]
+	}
+}
+
+// childIDHash returns the hash identifying a child of the given parent UI element instance. Children are identified
+// by their readable ID (see childID()) in the scope their parent was added in, so that they can be found by that ID
+// like any other UI element (e.g. "group__0" for a UIButtonGroup's first button, for Layout.DefaultHighlightID);
+// the ID is hashed piece by piece rather than built as a string.
+// If index is >= 0, it's appended to the suffix (e.g. for elements drawn in a UICollection).
+func childIDHash(parent *uiElementInstance, suffix string, index int) uint64 {
+	hash := hashIDBytes(hashIDBytes(parent.scope, parent.id), suffix)
+	if index >= 0 {
+		var digits [20]byte
+		hash = hashIDBytes(hash, strconv.AppendInt(digits[:0], int64(index), 10))
+	}
+	return hashIDTerminate(hash)
+}
+
+// childID returns the readable ID for a child of the given parent UI element instance; this is only built
+// once, when the child is first created.
+func childID(parent *uiElementInstance, suffix string, index int) string {
+	if index >= 0 {
+		return parent.id + suffix + strconv.Itoa(index)
+	}
+	return parent.id + suffix
+}
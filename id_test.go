@@ -0,0 +1,218 @@
+package gooey
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"testing"
+)
+
+func TestHashID(t *testing.T) {
+
+	// fnv64a returns the standard FNV-1a hash of the given bytes.
+	fnv64a := func(data []byte) uint64 {
+		h := fnv.New64a()
+		h.Write(data)
+		return h.Sum64()
+	}
+
+	intBytes := func(v int) []byte {
+		return binary.LittleEndian.AppendUint64(nil, uint64(v))
+	}
+
+	tests := []struct {
+		name string
+		got  uint64
+		want uint64
+	}{
+		// String IDs are hashed as FNV-1a with a 0xff terminator byte, so they match the standard library's hash.
+		{"empty string", hashIDString(idHashSeed, ""), fnv64a([]byte{0xff})},
+		{"string", hashIDString(idHashSeed, "button"), fnv64a([]byte("button\xff"))},
+		{"int", hashIDInt(idHashSeed, 42), fnv64a(intBytes(42))},
+		{"negative int", hashIDInt(idHashSeed, -1), fnv64a(intBytes(-1))},
+		{"scoped string", hashIDString(hashIDString(idHashSeed, "dialog"), "ok"), fnv64a([]byte("dialog\xffok\xff"))},
+		{"scoped int", hashIDString(hashIDInt(idHashSeed, 3), "icon"), fnv64a(append(intBytes(3), "icon\xff"...))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.got != test.want {
+				t.Errorf("got %x, want %x", test.got, test.want)
+			}
+		})
+	}
+
+	// Different ways of splitting the same characters across scopes shouldn't collide.
+	if hashIDString(hashIDString(idHashSeed, "a"), "bc") == hashIDString(hashIDString(idHashSeed, "ab"), "c") {
+		t.Error(`scopes "a" + "bc" and "ab" + "c" hash the same`)
+	}
+
+	if hashIDString(idHashSeed, "a") == hashIDString(hashIDString(idHashSeed, "a"), "a") {
+		t.Error(`ID "a" hashes the same in the root scope and in scope "a"`)
+	}
+
+}
+
+func TestIDScopes(t *testing.T) {
+
+	ctx := newTestContext()
+
+	var layout *Layout
+
+	testFrame(ctx, func() {
+
+		layout = ctx.NewLayout("inventory", 0, 0, 640, 360)
+
+		if root := layout.idScope(); root != idHashSeed {
+			t.Errorf("a new Layout's scope is %x, want the root scope %x", root, idHashSeed)
+		}
+
+		tests := []struct {
+			name  string
+			scope func()
+			want  uint64
+		}{
+			{"root", func() {}, idHashSeed},
+			{"string", func() { layout.PushID("slot") }, hashIDString(idHashSeed, "slot")},
+			{"int", func() { layout.PushIDInt(2) }, hashIDInt(idHashSeed, 2)},
+			{"nested", func() {
+				layout.PushID("slot")
+				layout.PushIDInt(2)
+			}, hashIDInt(hashIDString(idHashSeed, "slot"), 2)},
+			{"popped", func() {
+				layout.PushID("slot")
+				layout.PushIDInt(2)
+				layout.PopID()
+			}, hashIDString(idHashSeed, "slot")},
+			{"popped past root", func() {
+				layout.PushID("slot")
+				layout.PopID()
+				layout.PopID()
+			}, idHashSeed},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+
+				layout.idStack = layout.idStack[:0]
+				test.scope()
+
+				if got := layout.idScope(); got != test.want {
+					t.Errorf("idScope() = %x, want %x", got, test.want)
+				}
+
+			})
+		}
+
+		layout.idStack = layout.idStack[:0]
+
+		// Elements with the same ID in different scopes are different instances, and balanced PushID() / PopID() calls
+		// leave later elements in the root scope.
+		for i := 0; i < 3; i++ {
+			layout.PushIDInt(i)
+			NewUIWidget(testWidget{}).AddTo(layout, "icon")
+			layout.PopID()
+		}
+
+		NewUIWidget(testWidget{}).AddTo(layout, "icon")
+
+	})
+
+	if count := len(layout.existingUIElements.Data); count != 4 {
+		t.Errorf("%d UI element instances, want 4", count)
+	}
+
+	if layout.existingUIElements.Get("icon") == nil {
+		t.Error(`"icon" wasn't added in the root scope after balanced PushIDInt() / PopID() calls`)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, ok := layout.existingUIElements.Data[hashIDString(hashIDInt(idHashSeed, i), "icon")]; !ok {
+			t.Errorf(`"icon" wasn't added in scope %d`, i)
+		}
+	}
+
+	// The ID stack is cleared each frame, so an unbalanced PushID() doesn't leak into the next frame.
+	layout.PushID("unbalanced")
+
+	testFrame(ctx, func() {
+		if scope := ctx.NewLayout("inventory", 0, 0, 640, 360).idScope(); scope != idHashSeed {
+			t.Errorf("scope after an unbalanced PushID() is %x in the next frame, want the root scope", scope)
+		}
+	})
+
+}
+
+func TestChildIDsAreInParentScope(t *testing.T) {
+
+	// A UIButtonGroup's buttons are stacked vertically, and are found by their IDs ("group__0", etc.) in the scope the
+	// group was added in.
+	tests := []struct {
+		name  string
+		setup func(l *Layout)
+		input UpdateSettings
+		want  string
+	}{
+		{"DefaultHighlightID", func(l *Layout) { l.DefaultHighlightID = "group__1" }, UpdateSettings{}, "group__1"},
+		{"CustomHighlightingOrder default", func(l *Layout) {
+			l.CustomHighlightingOrder = []string{"group__2", "group__1"}
+		}, UpdateSettings{}, "group__1"},
+		{"CustomHighlightingOrder navigation", func(l *Layout) {
+			l.DefaultHighlightID = "group__2"
+			l.CustomHighlightingOrder = []string{"group__2", "group__0", "group__1"}
+		}, UpdateSettings{DownInput: true}, "group__0"},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			ctx := newTestContext()
+
+			var menu *Layout
+
+			for _, input := range []UpdateSettings{{}, test.input} {
+
+				testFrame(ctx, func() {
+					menu = ctx.NewLayout("menu", 0, 0, 100, 120)
+					menu.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+					test.setup(menu)
+					NewUIButtonGroup(NewUIButton(), "a", "b", "c").AddTo(menu, "group")
+				}, input)
+
+			}
+
+			if h := ctx.HighlightedUIElement(); h == nil || h.id != test.want {
+				t.Errorf("highlighted %v, want %s", h.ref(), test.want)
+			}
+
+			if menu.UIElement("group__0") == nil {
+				t.Error(`UIElement("group__0") didn't find the group's first button`)
+			}
+
+		})
+
+	}
+
+	// Children of UI elements added in a scope are in that scope, too.
+	ctx := newTestContext()
+
+	testFrame(ctx, func() {
+
+		menu := ctx.NewLayout("menu", 0, 0, 100, 120)
+
+		menu.PushID("dialog")
+		NewUIButtonGroup(NewUIButton(), "a", "b").AddTo(menu, "group")
+
+		if menu.UIElement("group__1") == nil {
+			t.Error(`UIElement("group__1") didn't find the group's second button in the group's scope`)
+		}
+
+		menu.PopID()
+
+		if menu.UIElement("group__1") != nil {
+			t.Error(`UIElement("group__1") found the group's second button outside of the group's scope`)
+		}
+
+	})
+
+}
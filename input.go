@@ -0,0 +1,79 @@
+package gooey
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// InputProvider is an interface for objects that supply input to a Context: pointer input (the mouse cursor, mouse
+// buttons, the mouse wheel, and touches), as well as the keys and gamepad buttons read by an ActionMap's KeySources and
+// GamepadButtonSources. By default, a Context uses EbitenInputProvider, which simply reads input from Ebitengine; you
+// can supply your own InputProvider to feed synthetic input to gooey (e.g. for headless testing, replays, or remote play).
+type InputProvider interface {
+	CursorPosition() (x, y float32)                                                               // The position of the mouse cursor.
+	IsMouseButtonPressed(button ebiten.MouseButton) bool                                          // If the given mouse button is pressed.
+	Wheel() (x, y float32)                                                                        // The mouse wheel's movement this frame.
+	AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID                                     // Appends the IDs of the currently active touches to the given slice.
+	TouchPosition(id ebiten.TouchID) (x, y float32)                                               // The position of the touch with the given ID.
+	IsKeyPressed(key ebiten.Key) bool                                                             // If the given keyboard key is pressed.
+	IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool // If the given button is pressed on the given standard-layout gamepad.
+}
+
+// EbitenInputProvider is an InputProvider that reads input directly from Ebitengine. This is the default
+// InputProvider for a Context.
+type EbitenInputProvider struct{}
+
+func (e EbitenInputProvider) CursorPosition() (x, y float32) {
+	cx, cy := ebiten.CursorPosition()
+	return float32(cx), float32(cy)
+}
+
+func (e EbitenInputProvider) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
+}
+
+func (e EbitenInputProvider) Wheel() (x, y float32) {
+	wx, wy := ebiten.Wheel()
+	return float32(wx), float32(wy)
+}
+
+func (e EbitenInputProvider) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	return ebiten.AppendTouchIDs(touches)
+}
+
+func (e EbitenInputProvider) TouchPosition(id ebiten.TouchID) (x, y float32) {
+	tx, ty := ebiten.TouchPosition(id)
+	return float32(tx), float32(ty)
+}
+
+func (e EbitenInputProvider) IsKeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (e EbitenInputProvider) IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return ebiten.IsStandardGamepadButtonPressed(id, button)
+}
+
+// SetInputProvider sets the InputProvider the default Context uses to read input.
+// Passing nil resets it to EbitenInputProvider.
+func SetInputProvider(provider InputProvider) {
+	defaultContext.SetInputProvider(provider)
+}
+
+// SetInputProvider sets the InputProvider the Context uses to read input.
+// Passing nil resets it to EbitenInputProvider.
+func (c *Context) SetInputProvider(provider InputProvider) {
+	if provider == nil {
+		provider = EbitenInputProvider{}
+	}
+	c.inputProvider = provider
+}
+
+// InputProvider returns the InputProvider the Context uses to read input.
+func (c *Context) InputProvider() InputProvider {
+	return c.inputProvider
+}
+
+// CursorPosition returns the position of the cursor for the current frame, as read from the Context's InputProvider in Begin().
+func (c *Context) CursorPosition() Vector2 {
+	return c.cursor
+}
@@ -0,0 +1,86 @@
+package gooey
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testInputProvider is an InputProvider with input set directly by tests.
+type testInputProvider struct {
+	cursor  Vector2
+	wheel   Vector2
+	buttons map[ebiten.MouseButton]bool
+	keys    map[ebiten.Key]bool
+	touches map[ebiten.TouchID]Vector2
+	custom  bool // Read by an InputSourceFunc, which isn't captured by InputRecordings itself
+}
+
+func (p *testInputProvider) CursorPosition() (x, y float32) {
+	return p.cursor.X, p.cursor.Y
+}
+
+func (p *testInputProvider) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return p.buttons[button]
+}
+
+func (p *testInputProvider) Wheel() (x, y float32) {
+	return p.wheel.X, p.wheel.Y
+}
+
+func (p *testInputProvider) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	return append(touches, slices.Sorted(maps.Keys(p.touches))...)
+}
+
+func (p *testInputProvider) TouchPosition(id ebiten.TouchID) (x, y float32) {
+	return p.touches[id].X, p.touches[id].Y
+}
+
+func (p *testInputProvider) IsKeyPressed(key ebiten.Key) bool {
+	return p.keys[key]
+}
+
+func (p *testInputProvider) IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return false
+}
+
+func TestInputProviderDrivesPointer(t *testing.T) {
+
+	ctx := newTestContext()
+
+	provider := &testInputProvider{}
+	ctx.SetInputProvider(provider)
+
+	// The cursor is over the second button, and clicks it.
+	provider.cursor = Vector2{20, 60}
+
+	pressed := map[string]int{}
+
+	for frame := 0; frame < 3; frame++ {
+
+		testFrame(ctx, func() {
+
+			menu := ctx.NewLayout("menu", 0, 0, 40, 80)
+			menu.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+			for _, id := range []string{"a", "b"} {
+				if NewUIButton().AddTo(menu, id) {
+					pressed[id]++
+				}
+			}
+
+		}, UpdateSettings{UseMouse: true, LeftMouseClick: frame == 1})
+
+	}
+
+	if cursor := ctx.CursorPosition(); cursor != provider.cursor {
+		t.Errorf("cursor at %v, want %v", cursor, provider.cursor)
+	}
+
+	if pressed["a"] != 0 || pressed["b"] != 1 {
+		t.Errorf("pressed a %d times and b %d times, want 0 and 1", pressed["a"], pressed["b"])
+	}
+
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"log"
+	"maps"
 	"sort"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -32,26 +33,63 @@ type Layout struct {
 	AutoScrollAcceleration float32 // The acceleration to the top speed (AutoScrollSpeed) for scrolling layouts when automatically scorolling.
 	autoScrollCurrentSpeed Vector2
 
+	ScrollFriction   float32 // How much of its momentum a drag-scrolled Layout keeps each frame after being released (from 0 to 1).
+	Overscroll       float32 // How far (in pixels) dragging can scroll the Layout past the ends of its contents, after which it springs back.
+	NoDragScrolling  bool    // If the Layout can't be scrolled by click- or touch-dragging it.
+	NoWheelScrolling bool    // If the Layout can't be scrolled with the mouse wheel.
+	scrollVelocity   Vector2
+	dragged          bool // If the Layout was dragged this frame
+	userScrolled     bool // If the Layout was scrolled by the wheel or dragging since the last navigation input; stops auto-scrolling
+
 	// A custom highlighting order. If set to nil or an empty slice, then highlighting is done based on UI elements' positions.
 	// Otherwise, the elements are highlighted in this given order. If an ID is given that doesn't exist, then it will
 	// revert to automatic position-based highlighting when attempting to highlight that element.
+	// The IDs are looked up in the Layout's root ID scope (i.e. outside of any PushID() calls).
 	CustomHighlightingOrder []string
 
+	// How navigation behaves at each side of the Layout. By default, navigation ignores the Layout's edges,
+	// considering the UI elements in every visible Layout. See Layout.SetEdgePolicy().
+	Edges LayoutEdges
+
+	// The ID of the UI element to highlight when highlighting enters the Layout for the first time (i.e. when no
+	// UI element in the Layout has been highlighted before). If empty or if the UI element isn't drawn, the usual
+	// default is used instead (the first highlightable UI element, or the last one drawn in CustomHighlightingOrder).
+	// Like CustomHighlightingOrder, the ID is looked up in the Layout's root ID scope.
+	DefaultHighlightID string
+
+	// How UI elements in the Layout that use directional input to change their value (i.e. UISliders and UICycleButtons)
+	// respond to it when highlighted, unless they have an EditMode set themselves. See EditModeAccept.
+	EditMode EditModeType
+
+	// NavigationStrategy, if set, overrides the Context's NavigationStrategy when navigating away from UI elements in this Layout.
+	NavigationStrategy NavigationStrategy
+
 	committedMaxRect   Rect
 	currentMaxRect     Rect
+	contentRect        Rect // The extent of the Layout's contents as of the last frame; unlike committedMaxRect, it isn't cleared when the Layout is reset
 	elementIndex       int
 	arranger           Arranger
 	Offset             Vector2
 	existingUIElements *sortedElementInstanceMap
+	idStack            []uint64
+	neighbors          map[uint64]NavigationNeighbors // Explicit navigation targets, keyed by UI element ID hash. See Layout.SetNeighbors().
+	callbacks          map[uint64]ElementCallbacks    // Functions to call when events happen to UI elements, keyed by UI element ID hash. See Layout.SetCallbacks().
+	visibleFrame       uint64                         // The Context frame in which the Layout was last retrieved with NewLayout()
+	context            *Context
 }
 
-// NewLayout creates a new Layout object for laying out elements in the given rectangle.
+// NewLayout creates a new Layout object for laying out elements in the given rectangle using the default Context.
 func NewLayout(id string, x, y, w, h float32) *Layout {
+	return defaultContext.NewLayout(id, x, y, w, h)
+}
+
+// NewLayout creates a new Layout object for laying out elements in the given rectangle.
+func (c *Context) NewLayout(id string, x, y, w, h float32) *Layout {
 
-	for _, l := range existingLayouts {
+	for _, l := range c.existingLayouts {
 		if l.ID == id {
 
-			for _, v := range visibleLayouts {
+			for _, v := range c.visibleLayouts {
 				if v.ID == id {
 					log.Println("gooey: cannot specify a new layout with the same ID multiple times")
 				}
@@ -59,7 +97,8 @@ func NewLayout(id string, x, y, w, h float32) *Layout {
 
 			l.arranger = &ArrangerFull{}
 
-			visibleLayouts = append(visibleLayouts, l)
+			c.visibleLayouts = append(c.visibleLayouts, l)
+			l.visibleFrame = c.drawFrame
 
 			l.Reset()
 			// l.uiDrawables = l.uiDrawables[:0]
@@ -74,18 +113,25 @@ func NewLayout(id string, x, y, w, h float32) *Layout {
 		arranger:               &ArrangerFull{},
 		AutoScrollSpeed:        8,
 		AutoScrollAcceleration: 0.5,
+		ScrollFriction:         0.9,
+		Overscroll:             32,
+		context:                c,
+		visibleFrame:           c.drawFrame,
 	}
-	visibleLayouts = append(visibleLayouts, l)
-	existingLayouts = append(existingLayouts, l)
+	c.visibleLayouts = append(c.visibleLayouts, l)
+	c.existingLayouts = append(c.existingLayouts, l)
 	return l
 }
 
-// Creates a new Layout from a given Rectangle and gives it an ID string.
+// Creates a new Layout from a given Rectangle and gives it an ID string using the default Context.
 func NewLayoutFromRect(id string, rect Rect) *Layout {
-	return NewLayout(id, rect.X, rect.Y, rect.W, rect.H)
+	return defaultContext.NewLayoutFromRect(id, rect)
 }
 
-var layoutsFromStrings = map[string]map[rune]*Layout{}
+// Creates a new Layout from a given Rectangle and gives it an ID string.
+func (c *Context) NewLayoutFromRect(id string, rect Rect) *Layout {
+	return c.NewLayout(id, rect.X, rect.Y, rect.W, rect.H)
+}
 
 /*
 	 NewLayoutsFromStrings creates a new series of layouts from a Rect and strings indicating the positioning and relative
@@ -109,17 +155,22 @@ var layoutsFromStrings = map[string]map[rune]*Layout{}
 	 as the key to return them.
 */
 func NewLayoutsFromStrings(idBase string, baseRect Rect, mappingStrings ...string) map[rune]*Layout {
+	return defaultContext.NewLayoutsFromStrings(idBase, baseRect, mappingStrings...)
+}
+
+// NewLayoutsFromStrings creates a new series of layouts owned by the Context; see the package-level NewLayoutsFromStrings for details.
+func (c *Context) NewLayoutsFromStrings(idBase string, baseRect Rect, mappingStrings ...string) map[rune]*Layout {
 
 	chars := []rune{}
 
-	if results, ok := layoutsFromStrings[idBase]; ok {
-		for c := range results {
-			chars = append(chars, c)
+	if results, ok := c.layoutsFromStrings[idBase]; ok {
+		for r := range results {
+			chars = append(chars, r)
 		}
 		// Sort for consistency
 		sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
-		for _, c := range chars {
-			NewLayout(results[c].ID, 0, 0, 0, 0)
+		for _, r := range chars {
+			c.NewLayout(results[r].ID, 0, 0, 0, 0)
 		}
 		return results
 	}
@@ -127,17 +178,17 @@ func NewLayoutsFromStrings(idBase string, baseRect Rect, mappingStrings ...strin
 	resultingLayouts := map[rune]*Layout{}
 
 	for _, row := range mappingStrings {
-		for _, c := range row {
+		for _, r := range row {
 
 			found := false
-			for _, c2 := range chars {
-				if c == c2 {
+			for _, r2 := range chars {
+				if r == r2 {
 					found = true
 					break
 				}
 			}
 			if !found {
-				chars = append(chars, c)
+				chars = append(chars, r)
 			}
 
 		}
@@ -147,9 +198,9 @@ func NewLayoutsFromStrings(idBase string, baseRect Rect, mappingStrings ...strin
 	// Sort for consistency
 	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
 
-	for _, c := range chars {
+	for _, r := range chars {
 
-		if c == ' ' {
+		if r == ' ' {
 			continue
 		}
 
@@ -165,7 +216,7 @@ func NewLayoutsFromStrings(idBase string, baseRect Rect, mappingStrings ...strin
 				tx := baseRect.X + (float32(x)/float32(len(row)))*baseRect.W
 				tx2 := baseRect.X + (float32(x+1)/float32(len(row)))*baseRect.W
 
-				if c == char {
+				if r == char {
 
 					if !set || tx < rect.X {
 						rect.X = tx
@@ -191,27 +242,42 @@ func NewLayoutsFromStrings(idBase string, baseRect Rect, mappingStrings ...strin
 		}
 
 		if set {
-			resultingLayouts[c] = NewLayout(idBase+"_"+string(c), rect.X, rect.Y, rect.W, rect.H)
+			resultingLayouts[r] = c.NewLayout(idBase+"_"+string(r), rect.X, rect.Y, rect.W, rect.H)
 			// resultingLayouts = append(resultingLayouts, NewLayout(idBase+"_"+string(c), rect.X, rect.Y, rect.W, rect.H))
 		}
 
 	}
 
-	layoutsFromStrings[idBase] = resultingLayouts
+	c.layoutsFromStrings[idBase] = resultingLayouts
 
 	return resultingLayouts
 
 }
 
 func (l *Layout) Clone(newID string) *Layout {
-	n := NewLayout(newID, l.Rect.X, l.Rect.Y, l.Rect.W, l.Rect.H)
+	n := l.context.NewLayout(newID, l.Rect.X, l.Rect.Y, l.Rect.W, l.Rect.H)
 	n.arranger = l.arranger
 	n.AutoScrollAcceleration = l.AutoScrollAcceleration
 	n.AutoScrollSpeed = l.AutoScrollSpeed
+	n.ScrollFriction = l.ScrollFriction
+	n.Overscroll = l.Overscroll
+	n.NoDragScrolling = l.NoDragScrolling
+	n.NoWheelScrolling = l.NoWheelScrolling
 	n.CustomHighlightingOrder = l.CustomHighlightingOrder
+	n.neighbors = maps.Clone(l.neighbors)
+	n.DefaultHighlightID = l.DefaultHighlightID
+	n.Edges = l.Edges
+	n.callbacks = maps.Clone(l.callbacks)
+	n.EditMode = l.EditMode
+	n.NavigationStrategy = l.NavigationStrategy
 	return n
 }
 
+// Context returns the Context that owns the Layout.
+func (l *Layout) Context() *Context {
+	return l.context
+}
+
 func (l *Layout) String() string {
 	return fmt.Sprintf("%v : { %d, %d, %d, %d }", l.ID, int(l.Rect.X), int(l.Rect.Y), int(l.Rect.W), int(l.Rect.H))
 }
@@ -227,7 +293,7 @@ func (l *Layout) String() string {
 // AlignToScreenbuffer aligns an Area to the bounds of gooey's screenbuffer using an Alignment constant,
 // with the desired padding in pixels.
 func (l *Layout) AlignToScreenbuffer(alignment Alignment, padding float32) *Layout {
-	l.Rect = l.Rect.AlignToScreenbuffer(alignment, padding)
+	l.Rect = l.Rect.AlignToImage(l.context.screenBuffer, alignment, padding)
 	return l
 }
 
@@ -282,13 +348,14 @@ func (l *Layout) AlignToLayout(other *Layout, alignment Alignment, padding float
 
 func (l *Layout) subscreen() *ebiten.Image {
 	// return screenBuffer
-	return screenBuffer.SubImage(image.Rect(int(l.Rect.X), int(l.Rect.Y), int(l.Rect.X)+int(l.Rect.W), int(l.Rect.Y)+int(l.Rect.H))).(*ebiten.Image)
+	return l.context.screenBuffer.SubImage(image.Rect(int(l.Rect.X), int(l.Rect.Y), int(l.Rect.X)+int(l.Rect.W), int(l.Rect.Y)+int(l.Rect.H))).(*ebiten.Image)
 }
 
 // Reset resets the Layout so that any additionally drawn UI elements' positions
 // go back to the start.
 func (l *Layout) Reset() {
 	l.elementIndex = 0
+	l.idStack = l.idStack[:0]
 	l.committedMaxRect = Rect{}
 	l.currentMaxRect = Rect{}
 }
@@ -342,16 +409,59 @@ func (l *Layout) SetCustomArranger(layoutFunc ArrangeFunc) *Layout {
 
 func (l *Layout) add(id string, drawable UIElement, drawCall *DrawCall) {
 
-	inst := l.existingUIElements.Add(id)
+	scope := l.idScope()
+
+	inst, created := l.existingUIElements.Add(hashIDString(scope, id))
+	if created {
+		inst.id = id
+		inst.scope = scope
+	}
+
+	l.addInstance(inst, drawable, drawCall)
+	l.Advance(1)
+
+}
+
+// addChild adds a UI element as a child of the given parent UI element instance; the child is identified by the
+// parent's ID with the suffix (and index, if >= 0) appended, in the parent's ID scope. Unlike add(), this doesn't
+// advance the Layout, so the child is drawn in its parent's place.
+func (l *Layout) addChild(parent *uiElementInstance, suffix string, index int, drawable UIElement, drawCall *DrawCall) {
+
+	inst, created := l.existingUIElements.Add(childIDHash(parent, suffix, index))
+	if created {
+		inst.id = childID(parent, suffix, index)
+		inst.scope = parent.scope
+		inst.parent = parent
+	}
+
+	l.addInstance(inst, drawable, drawCall)
+
+}
+
+func (l *Layout) addInstance(inst *uiElementInstance, drawable UIElement, drawCall *DrawCall) {
+
+	l.context.internalStateAccessOnce(inst)
 
 	inst.layout = l
 
 	drawCall.ElementIndex = l.elementIndex
 	drawCall.Instance = inst
+	inst.elementIndex = l.elementIndex
+
+	// Draw the element as the player highlighting it (if any), so that it responds to that player's input.
+	ctx := l.context
+	activePlayer := ctx.playerState
 
-	drawCall.isHighlighted = inst == highlightedElement
+	if p := ctx.highlightingPlayer(inst); p != nil {
+		ctx.playerState = p
+		drawCall.isHighlighted = true
+	} else {
+		drawCall.isHighlighted = false
+	}
 
-	inst.drawable = drawable
+	drawCall.player = ctx.playerState.id
+
+	inst.setDrawable(drawable)
 	inst.wasDrawn = true
 
 	inst.prevRect = inst.currentRect
@@ -366,6 +476,8 @@ func (l *Layout) add(id string, drawable UIElement, drawCall *DrawCall) {
 	inst.drawable.draw(drawCall) // the state is set here
 	inst.currentRect = drawCall.Rect
 
+	ctx.playerState = activePlayer
+
 	if drawCall.InfluenceScrolling {
 
 		emptyRect := l.currentMaxRect.IsZero()
@@ -388,12 +500,34 @@ func (l *Layout) add(id string, drawable UIElement, drawCall *DrawCall) {
 
 	}
 
-	l.Advance(1)
+}
+
+// restoreHighlight returns the UI element instance to highlight for the active player when highlighting enters the given
+// Layout: the one they last highlighted in the Layout (unless UpdateSettings.NoRememberHighlighting is set), or the one
+// identified by DefaultHighlightID otherwise. Returns nil if neither is drawn and highlightable.
+func (c *Context) restoreHighlight(l *Layout) *uiElementInstance {
+	if !c.updateSettings.NoRememberHighlighting {
+		if element := c.remembered(l); element != nil {
+			return element
+		}
+	}
+	return l.defaultHighlight()
+}
 
+// defaultHighlight returns the Layout's DefaultHighlightID UI element instance, or nil if it's unset, or isn't drawn and highlightable.
+func (l *Layout) defaultHighlight() *uiElementInstance {
+	if l.DefaultHighlightID == "" {
+		return nil
+	}
+	element := l.existingUIElements.Get(l.DefaultHighlightID)
+	if element == nil || !element.wasDrawn || !element.drawable.highlightable() {
+		return nil
+	}
+	return element
 }
 
 func (l *Layout) isVisible() bool {
-	for _, layout := range visibleLayouts {
+	for _, layout := range l.context.visibleLayouts {
 		if layout == l {
 			return true
 		}
@@ -401,10 +535,10 @@ func (l *Layout) isVisible() bool {
 	return false
 }
 
-// Returns the UI element instance of the given ID string.
+// Returns the UI element instance of the given ID string in the Layout's current ID scope (see PushID()).
 // If no such ID is found, the function returns nil.
 func (l *Layout) UIElement(id string) *uiElementInstance {
-	if element, ok := l.existingUIElements.Data[id]; ok {
+	if element, ok := l.existingUIElements.Data[hashIDString(l.idScope(), id)]; ok {
 		return element
 	}
 	return nil
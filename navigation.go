@@ -0,0 +1,785 @@
+package gooey
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// NavigationCandidate is a highlightable UI element that a NavigationStrategy can choose to highlight.
+type NavigationCandidate struct {
+	ID     string  // The ID of the UI element.
+	Layout *Layout // The Layout the UI element was drawn in.
+	Rect   Rect    // Where the UI element was drawn on-screen.
+	Index  int     // The element index of the UI element in its Layout (see DrawCall.ElementIndex).
+}
+
+// NavigationStrategy determines which UI element gets highlighted when a directional (right, left, up, down) or
+// next / previous input is pressed. The Context's NavigationStrategy is used by default, and can be overridden
+// for individual Layouts by setting Layout.NavigationStrategy.
+//
+// If neither is set, a ConeNavigationStrategy is used. Note that this changes how older versions of gooey navigated:
+// directional input now stops at the last UI element in that direction, rather than wrapping around to the farthest
+// one on the opposite side. Set Context.NavigationStrategy to LegacyNavigationStrategy{} to keep the old behavior.
+type NavigationStrategy interface {
+	// Navigate receives every visible, highlightable UI element as a candidate (including the currently highlighted
+	// one, at the current index), and returns the index of the candidate to highlight, or -1 to not move the highlight.
+	Navigate(current int, direction NavigationInput, candidates []NavigationCandidate) int
+}
+
+// ConeNavigationStrategy is the default NavigationStrategy. For directional input, it considers candidates whose
+// centers lie within a cone extending from the current element in the given direction, as well as any candidates
+// that overlap the current element on the perpendicular axis (i.e. elements in the same row for left and right, or
+// in the same column for up and down). Candidates are scored by the gap between the elements along the direction
+// of movement plus the perpendicular gap between them (scaled by PerpendicularWeight), and the candidate with the
+// lowest score is highlighted. If there's no candidate in the given direction, the highlight doesn't move.
+// Next and previous input move through the elements in reading order (left-to-right, top-to-bottom), wrapping around.
+type ConeNavigationStrategy struct {
+	ConeAngle           float32 // The angle (in degrees) to either side of the direction of movement that candidates' centers can lie within.
+	PerpendicularWeight float32 // How heavily the gap on the axis perpendicular to the direction of movement counts against a candidate.
+}
+
+// NewConeNavigationStrategy creates a new ConeNavigationStrategy with default settings.
+func NewConeNavigationStrategy() ConeNavigationStrategy {
+	return ConeNavigationStrategy{
+		ConeAngle:           50,
+		PerpendicularWeight: 2,
+	}
+}
+
+func (s ConeNavigationStrategy) WithConeAngle(degrees float32) ConeNavigationStrategy {
+	s.ConeAngle = degrees
+	return s
+}
+
+func (s ConeNavigationStrategy) WithPerpendicularWeight(weight float32) ConeNavigationStrategy {
+	s.PerpendicularWeight = weight
+	return s
+}
+
+func (s ConeNavigationStrategy) Navigate(current int, direction NavigationInput, candidates []NavigationCandidate) int {
+
+	var dir Vector2
+
+	switch direction {
+	case NavigationInputRight:
+		dir = Vector2{1, 0}
+	case NavigationInputLeft:
+		dir = Vector2{-1, 0}
+	case NavigationInputUp:
+		dir = Vector2{0, -1}
+	case NavigationInputDown:
+		dir = Vector2{0, 1}
+	case NavigationInputNext, NavigationInputPrev:
+		return navigateReadingOrder(current, direction, candidates)
+	default:
+		return -1
+	}
+
+	return s.navigateVector(current, dir, candidates)
+
+}
+
+// NavigateDiagonal navigates in the given direction, which doesn't need to be axis-aligned. See DiagonalNavigationStrategy.
+func (s ConeNavigationStrategy) NavigateDiagonal(current int, direction Vector2, candidates []NavigationCandidate) int {
+	return s.navigateVector(current, direction.Unit(), candidates)
+}
+
+func (s ConeNavigationStrategy) navigateVector(current int, dir Vector2, candidates []NavigationCandidate) int {
+
+	currentRect := candidates[current].Rect
+	currentCenter := currentRect.Center()
+	maxAngle := float64(s.ConeAngle) * math.Pi / 180
+
+	closest := -1
+	closestScore := float32(0)
+	closestDistance := float32(0)
+
+	for i, cand := range candidates {
+
+		if i == current {
+			continue
+		}
+
+		r := cand.Rect
+		delta := r.Center().Sub(currentCenter)
+
+		var gap, overlap, along, across float32
+
+		if dir.X != 0 && dir.Y != 0 {
+			// For diagonal directions, there's no single perpendicular axis to overlap on, so go by the elements' centers.
+			along = delta.Dot(dir)
+			across = delta.X*dir.Y - delta.Y*dir.X
+			gap = along
+			overlap = -float32(math.Abs(float64(across)))
+		} else if dir.X != 0 {
+			along = delta.X * dir.X
+			across = delta.Y
+			overlap = r.overlappingAxisY(currentRect)
+			if dir.X > 0 {
+				gap = r.X - currentRect.Right()
+			} else {
+				gap = currentRect.X - r.Right()
+			}
+		} else {
+			along = delta.Y * dir.Y
+			across = delta.X
+			overlap = r.overlappingAxisX(currentRect)
+			if dir.Y > 0 {
+				gap = r.Y - currentRect.Bottom()
+			} else {
+				gap = currentRect.Y - r.Bottom()
+			}
+		}
+
+		if along <= 0 {
+			continue
+		}
+
+		if overlap <= 0 && math.Atan2(math.Abs(float64(across)), float64(along)) > maxAngle {
+			continue
+		}
+
+		// Overlapping elements have no perpendicular gap, and so are preferred over those that are off to the side.
+		score := max(gap, 0) + max(-overlap, 0)*s.PerpendicularWeight
+		distance := delta.Magnitude()
+
+		if closest < 0 || score < closestScore || (score == closestScore && distance < closestDistance) {
+			closest = i
+			closestScore = score
+			closestDistance = distance
+		}
+
+	}
+
+	return closest
+
+}
+
+// LegacyNavigationStrategy is the NavigationStrategy gooey used before NavigationStrategies were introduced.
+// For directional input, it highlights the closest element whose center lies past the current element's edge in
+// the given direction. If there's none, it wraps around to the farthest element on the opposite side that overlaps
+// the current element on the perpendicular axis.
+// Next and previous input move through the elements in reading order (left-to-right, top-to-bottom), wrapping around.
+type LegacyNavigationStrategy struct{}
+
+func (s LegacyNavigationStrategy) Navigate(current int, direction NavigationInput, candidates []NavigationCandidate) int {
+
+	currentRect := candidates[current].Rect
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+
+	sortByDistance := func(targetPos Vector2) {
+
+		sort.Slice(order, func(i, j int) bool {
+			ir := candidates[order[i]].Rect
+			jr := candidates[order[j]].Rect
+			return ir.Center().DistanceTo(targetPos) < jr.Center().DistanceTo(targetPos)
+		})
+
+	}
+
+	// firstWhere returns the first candidate (other than the current one) in the current order that passes the given test.
+	firstWhere := func(test func(r Rect) bool) int {
+		for _, i := range order {
+			if i != current && test(candidates[i].Rect) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// bestWhere returns the candidate (other than the current one) that overlaps the given axis and is best according to the given test.
+	bestWhere := func(overlapping func(r Rect) float32, better func(r, best Rect) bool) int {
+		closest := -1
+		for _, i := range order {
+			if i == current {
+				continue
+			}
+			r := candidates[i].Rect
+			if overlapping(r) > 0 && (closest < 0 || better(r, candidates[closest].Rect)) {
+				closest = i
+			}
+		}
+		return closest
+	}
+
+	overlappingY := func(r Rect) float32 { return r.overlappingAxisY(currentRect) }
+	overlappingX := func(r Rect) float32 { return r.overlappingAxisX(currentRect) }
+
+	closest := -1
+
+	switch direction {
+	case NavigationInputRight:
+		sortByDistance(currentRect.Center().SetX(currentRect.Right() + 1))
+		closest = firstWhere(func(r Rect) bool { return r.Center().X > currentRect.Right() })
+		// If there's nothing to the right, go for the element farthest to the left on the same row
+		if closest < 0 {
+			closest = bestWhere(overlappingY, func(r, best Rect) bool { return r.X < best.X })
+		}
+
+	case NavigationInputLeft:
+		sortByDistance(currentRect.Center().SetX(currentRect.X - 1))
+		closest = firstWhere(func(r Rect) bool { return r.Center().X < currentRect.X })
+		if closest < 0 {
+			closest = bestWhere(overlappingY, func(r, best Rect) bool { return r.X > best.X })
+		}
+
+	case NavigationInputUp:
+		sortByDistance(currentRect.Center().SetY(currentRect.Y - 1))
+		closest = firstWhere(func(r Rect) bool { return r.Center().Y < currentRect.Y })
+		if closest < 0 {
+			closest = bestWhere(overlappingX, func(r, best Rect) bool { return r.Y > best.Y })
+		}
+
+	case NavigationInputDown:
+		sortByDistance(currentRect.Center().SetY(currentRect.Bottom() + 1))
+		closest = firstWhere(func(r Rect) bool { return r.Center().Y > currentRect.Bottom() })
+		if closest < 0 {
+			closest = bestWhere(overlappingX, func(r, best Rect) bool { return r.Y < best.Y })
+		}
+
+	case NavigationInputNext, NavigationInputPrev:
+		closest = navigateReadingOrder(current, direction, candidates)
+	}
+
+	return closest
+
+}
+
+// GridNavigationStrategy is a NavigationStrategy for Layouts arranged with an ArrangerGrid. Rather than comparing
+// Rects, directional input moves by grid cell using the UI elements' element indices, the ArrangerGrid's ElementCount,
+// and its Direction, so it isn't thrown off by cells with differently sized graphics or cells that are scrolled out of
+// view. Cells without a highlightable UI element (e.g. disabled buttons) are skipped.
+// Moving past the edge of the grid wraps around if wrapping is enabled for that axis; otherwise, navigation continues
+// to the closest UI element in other Layouts (using a ConeNavigationStrategy), if any.
+// Next and previous input move through the grid's UI elements by element index, wrapping around.
+// For Layouts that don't use an ArrangerGrid, it behaves like a ConeNavigationStrategy.
+type GridNavigationStrategy struct {
+	WrapHorizontal bool // Whether moving left or right past the edge of the grid wraps around to the other side.
+	WrapVertical   bool // Whether moving up or down past the edge of the grid wraps around to the other side.
+}
+
+// NewGridNavigationStrategy creates a new GridNavigationStrategy.
+func NewGridNavigationStrategy() GridNavigationStrategy {
+	return GridNavigationStrategy{}
+}
+
+func (s GridNavigationStrategy) WithWrapHorizontal(wrap bool) GridNavigationStrategy {
+	s.WrapHorizontal = wrap
+	return s
+}
+
+func (s GridNavigationStrategy) WithWrapVertical(wrap bool) GridNavigationStrategy {
+	s.WrapVertical = wrap
+	return s
+}
+
+func (s GridNavigationStrategy) Navigate(current int, direction NavigationInput, candidates []NavigationCandidate) int {
+
+	layout := candidates[current].Layout
+
+	var grid ArrangerGrid
+
+	switch arranger := layout.Arranger().(type) {
+	case ArrangerGrid:
+		grid = arranger
+	case *ArrangerGrid:
+		grid = *arranger
+	default:
+		return NewConeNavigationStrategy().Navigate(current, direction, candidates)
+	}
+
+	// The candidates in the grid, by element index
+	cells := map[int]int{}
+	maxIndex := 0
+
+	for i, cand := range candidates {
+		if cand.Layout == layout && i != current {
+			if _, exists := cells[cand.Index]; !exists {
+				cells[cand.Index] = i
+			}
+			maxIndex = max(maxIndex, cand.Index)
+		}
+	}
+
+	currentIndex := candidates[current].Index
+	maxIndex = max(maxIndex, currentIndex)
+
+	if direction == NavigationInputNext || direction == NavigationInputPrev {
+
+		closest := -1
+		for index, i := range cells {
+			if closest < 0 {
+				closest = i
+				continue
+			}
+			best := candidates[closest].Index
+			if direction == NavigationInputNext {
+				// The next index after the current one, or the first index if there's none after it
+				if (index > currentIndex) != (best > currentIndex) {
+					if index > currentIndex {
+						closest = i
+					}
+				} else if index < best {
+					closest = i
+				}
+			} else {
+				if (index < currentIndex) != (best < currentIndex) {
+					if index < currentIndex {
+						closest = i
+					}
+				} else if index > best {
+					closest = i
+				}
+			}
+		}
+		return closest
+
+	}
+
+	count := max(grid.ElementCount, 1)
+
+	columns, rows := count, maxIndex/count+1
+	toCell := func(index int) (int, int) { return index % count, index / count }
+	toIndex := func(x, y int) int { return y*count + x }
+
+	if grid.Direction == ArrangeDirectionColumn {
+		columns, rows = rows, columns
+		toCell = func(index int) (int, int) { return index / count, index % count }
+		toIndex = func(x, y int) int { return x*count + y }
+	}
+
+	dx, dy := 0, 0
+	wrap := false
+
+	switch direction {
+	case NavigationInputRight:
+		dx, wrap = 1, s.WrapHorizontal
+	case NavigationInputLeft:
+		dx, wrap = -1, s.WrapHorizontal
+	case NavigationInputDown:
+		dy, wrap = 1, s.WrapVertical
+	case NavigationInputUp:
+		dy, wrap = -1, s.WrapVertical
+	default:
+		return -1
+	}
+
+	x, y := toCell(currentIndex)
+
+	for step := 0; step < columns*rows; step++ {
+
+		x += dx
+		y += dy
+
+		if x < 0 || x >= columns || y < 0 || y >= rows {
+			if !wrap {
+				break
+			}
+			x = (x + columns) % columns
+			y = (y + rows) % rows
+		}
+
+		if i, exists := cells[toIndex(x, y)]; exists {
+			return i
+		}
+
+	}
+
+	// We're at the edge of the grid, so leave it for the closest UI element in another Layout.
+	others := make([]NavigationCandidate, 0, len(candidates))
+	indices := make([]int, 0, len(candidates))
+	otherCurrent := 0
+
+	for i, cand := range candidates {
+		if i == current {
+			otherCurrent = len(others)
+		} else if cand.Layout == layout {
+			continue
+		}
+		others = append(others, cand)
+		indices = append(indices, i)
+	}
+
+	if next := NewConeNavigationStrategy().Navigate(otherCurrent, direction, others); next >= 0 {
+		return indices[next]
+	}
+
+	return -1
+
+}
+
+// navigateReadingOrder returns the index of the candidate before or after the current one in reading order
+// (left-to-right, top-to-bottom), wrapping around at either end.
+func navigateReadingOrder(current int, direction NavigationInput, candidates []NavigationCandidate) int {
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		ir := candidates[order[i]].Rect
+		jr := candidates[order[j]].Rect
+		return ir.X+(ir.Y*100000) < jr.X+(jr.Y*100000)
+	})
+
+	for index, i := range order {
+		if i != current {
+			continue
+		}
+		if direction == NavigationInputNext {
+			return order[(index+1)%len(order)]
+		}
+		return order[(index-1+len(order))%len(order)]
+	}
+
+	return -1
+
+}
+
+// navigationStrategy returns the NavigationStrategy to use when navigating away from an element in the given Layout.
+func (c *Context) navigationStrategy(layout *Layout) NavigationStrategy {
+	if layout.NavigationStrategy != nil {
+		return layout.NavigationStrategy
+	}
+	if c.NavigationStrategy != nil {
+		return c.NavigationStrategy
+	}
+	return NewConeNavigationStrategy()
+}
+
+// navigate moves the highlight from the currently highlighted element to another one out of the given
+// highlightable elements using the appropriate NavigationStrategy, according to the queued input and the
+// highlighted element's Layout's edge policies.
+func (c *Context) navigate(elements []*uiElementInstance) {
+
+	direction := NavigationInput(c.queuedInput)
+
+	switch direction {
+	case NavigationInputRight, NavigationInputLeft, NavigationInputUp, NavigationInputDown, NavigationInputNext, NavigationInputPrev:
+	default:
+		return
+	}
+
+	current := c.highlightedElement
+	layout := current.layout
+	strategy := c.navigationStrategy(layout)
+	edge := layout.Edges.edge(direction)
+
+	if diagonal, ok := strategy.(DiagonalNavigationStrategy); ok && !c.analogDirection.IsZero() {
+		strategy = diagonalStrategy{DiagonalNavigationStrategy: diagonal, direction: c.analogDirection}
+	}
+
+	if edge.Policy == EdgePolicyOpen {
+		if next := navigateFrom(strategy, current, current.currentRect, direction, elements); next != nil {
+			c.highlightedElement = next
+		}
+		return
+	}
+
+	inLayout := elementsInLayout(elements, layout)
+
+	if next := navigateFrom(strategy, current, current.currentRect, direction, inLayout); next != nil {
+		c.highlightedElement = next
+		return
+	}
+
+	// There's nothing further in the given direction within the Layout, so we're at its edge.
+
+	switch edge.Policy {
+
+	case EdgePolicyWrap:
+		// Navigate from just outside the opposite side of the Layout's elements.
+		from := rectOutside(current.currentRect, elementBounds(inLayout), direction)
+		if next := navigateFrom(strategy, current, from, direction, inLayout); next != nil {
+			c.highlightedElement = next
+		}
+
+	case EdgePolicyJump:
+
+		var target *Layout
+		for _, l := range c.visibleLayouts {
+			if l.ID == edge.Layout && !c.highlightingLocked(l) {
+				target = l
+				break
+			}
+		}
+
+		if target == nil {
+			return
+		}
+
+		targetElements := elementsInLayout(elements, target)
+
+		if len(targetElements) == 0 {
+			return
+		}
+
+		// Restore the target Layout's previously highlighted (or default) element if it's drawn; otherwise, navigate from
+		// just outside the near side of the target Layout's elements. If the strategy doesn't find anything from there
+		// (i.e. the Layouts don't line up), go with the closest element.
+		if restored := c.restoreHighlight(target); restored != nil && slices.Contains(targetElements, restored) {
+			c.highlightedElement = restored
+			return
+		}
+
+		from := rectOutside(current.currentRect, elementBounds(targetElements), direction)
+		next := navigateFrom(strategy, current, from, direction, targetElements)
+
+		if next == nil {
+			for _, e := range targetElements {
+				if next == nil || e.currentRect.Center().DistanceSquaredTo(from.Center()) < next.currentRect.Center().DistanceSquaredTo(from.Center()) {
+					next = e
+				}
+			}
+		}
+
+		c.highlightedElement = next
+
+	}
+
+}
+
+// navigateFrom uses the given NavigationStrategy to navigate from the current element, placed at the given Rect, to one
+// of the given elements. If the current element isn't one of the elements given, it's added as a candidate for the
+// strategy. Returns nil if the strategy doesn't choose an element.
+func navigateFrom(strategy NavigationStrategy, current *uiElementInstance, from Rect, direction NavigationInput, elements []*uiElementInstance) *uiElementInstance {
+
+	currentIndex := -1
+	candidates := make([]NavigationCandidate, 0, len(elements)+1)
+
+	for i, e := range elements {
+		rect := e.currentRect
+		if e == current {
+			currentIndex = i
+			rect = from
+		}
+		candidates = append(candidates, NavigationCandidate{
+			ID:     e.id,
+			Layout: e.layout,
+			Rect:   rect,
+			Index:  e.elementIndex,
+		})
+	}
+
+	if currentIndex < 0 {
+		currentIndex = len(candidates)
+		candidates = append(candidates, NavigationCandidate{
+			ID:     current.id,
+			Layout: current.layout,
+			Rect:   from,
+			Index:  current.elementIndex,
+		})
+	}
+
+	if next := strategy.Navigate(currentIndex, direction, candidates); next >= 0 && next < len(elements) && next != currentIndex {
+		return elements[next]
+	}
+
+	return nil
+
+}
+
+func elementsInLayout(elements []*uiElementInstance, layout *Layout) []*uiElementInstance {
+	inLayout := []*uiElementInstance{}
+	for _, e := range elements {
+		if e.layout == layout {
+			inLayout = append(inLayout, e)
+		}
+	}
+	return inLayout
+}
+
+// elementBounds returns the Rect containing all of the given elements.
+func elementBounds(elements []*uiElementInstance) Rect {
+
+	if len(elements) == 0 {
+		return Rect{}
+	}
+
+	left, top := elements[0].currentRect.X, elements[0].currentRect.Y
+	right, bottom := elements[0].currentRect.Right(), elements[0].currentRect.Bottom()
+
+	for _, e := range elements[1:] {
+		left = min(left, e.currentRect.X)
+		top = min(top, e.currentRect.Y)
+		right = max(right, e.currentRect.Right())
+		bottom = max(bottom, e.currentRect.Bottom())
+	}
+
+	return Rect{left, top, right - left, bottom - top}
+
+}
+
+// rectOutside returns the given Rect moved on the axis of the given direction to lie just outside the given bounds,
+// on the side that navigating in that direction would enter from.
+func rectOutside(r Rect, bounds Rect, direction NavigationInput) Rect {
+	switch direction {
+	case NavigationInputRight:
+		r.X = bounds.X - r.W - 1
+	case NavigationInputLeft:
+		r.X = bounds.Right() + 1
+	case NavigationInputDown:
+		r.Y = bounds.Y - r.H - 1
+	case NavigationInputUp:
+		r.Y = bounds.Bottom() + 1
+	}
+	return r
+}
+
+// EdgePolicyType specifies what happens when navigating past the edge of a Layout (i.e. when there's no further
+// highlightable UI element in that direction within the Layout).
+type EdgePolicyType int
+
+const (
+	EdgePolicyOpen EdgePolicyType = iota // Navigation considers the UI elements in every visible Layout, ignoring the Layout's edges. This is the default behavior.
+	EdgePolicyStop                       // Navigation stays within the Layout, and stops at its edge.
+	EdgePolicyWrap                       // Navigation stays within the Layout, and wraps around to the other side at its edge.
+	EdgePolicyJump                       // Navigation stays within the Layout, and jumps to the Layout specified by LayoutEdge.Layout at its edge.
+)
+
+// LayoutEdge specifies how navigation behaves at one side of a Layout.
+type LayoutEdge struct {
+	Policy EdgePolicyType
+	Layout string // The ID of the Layout to jump to for EdgePolicyJump.
+}
+
+// LayoutEdges specifies how navigation behaves at each side of a Layout.
+type LayoutEdges struct {
+	Up, Down, Left, Right LayoutEdge
+}
+
+func (e LayoutEdges) edge(direction NavigationInput) LayoutEdge {
+	switch direction {
+	case NavigationInputUp:
+		return e.Up
+	case NavigationInputDown:
+		return e.Down
+	case NavigationInputLeft:
+		return e.Left
+	case NavigationInputRight:
+		return e.Right
+	}
+	return LayoutEdge{}
+}
+
+// SetEdgePolicy sets the edge policy for all sides of the Layout. To jump to other Layouts, set the Layout's
+// Edges individually instead:
+//
+//	sidebar.SetEdgePolicy(gooey.EdgePolicyStop)
+//	sidebar.Edges.Right = gooey.LayoutEdge{Policy: gooey.EdgePolicyJump, Layout: "content"}
+func (l *Layout) SetEdgePolicy(policy EdgePolicyType) {
+	l.Edges = LayoutEdges{
+		Up:    LayoutEdge{Policy: policy},
+		Down:  LayoutEdge{Policy: policy},
+		Left:  LayoutEdge{Policy: policy},
+		Right: LayoutEdge{Policy: policy},
+	}
+}
+
+// NavigationTarget identifies a UI element to highlight when navigating in a specific direction.
+type NavigationTarget struct {
+	Layout string // The ID of the Layout the UI element is in. If empty, the UI element is in the same Layout as the element being navigated away from.
+	ID     string // The ID of the UI element. If empty, the target is unset.
+	hash   uint64
+}
+
+// NavigationNeighbors specifies the UI elements to highlight when navigating away from a UI element in each direction.
+// Directions whose targets are unset, or whose targets aren't visible and highlightable, fall back to the normal
+// navigation behavior (the Layout's CustomHighlightingOrder or the NavigationStrategy).
+type NavigationNeighbors struct {
+	Up, Down, Left, Right, Next, Prev NavigationTarget
+}
+
+func (n NavigationNeighbors) target(direction NavigationInput) NavigationTarget {
+	switch direction {
+	case NavigationInputUp:
+		return n.Up
+	case NavigationInputDown:
+		return n.Down
+	case NavigationInputLeft:
+		return n.Left
+	case NavigationInputRight:
+		return n.Right
+	case NavigationInputNext:
+		return n.Next
+	case NavigationInputPrev:
+		return n.Prev
+	}
+	return NavigationTarget{}
+}
+
+// SetNeighbors sets the UI elements to navigate to from the UI element with the given ID in the Layout.
+// This allows for menus whose navigation can't be expressed by the elements' positions or a single
+// CustomHighlightingOrder, like cross-shaped menus:
+//
+//	layout.SetNeighbors("helmet", gooey.NavigationNeighbors{
+//		Down: gooey.NavigationTarget{ID: "armor"},
+//		Left: gooey.NavigationTarget{Layout: "inventory", ID: "slot0"},
+//	})
+//
+// The ID, as well as the IDs of targets in the same Layout, are in the Layout's current ID scope (see PushID()), so
+// neighbors can be set for UI elements drawn by reusable components. Targets in other Layouts are in their root scope.
+func (l *Layout) SetNeighbors(id string, neighbors NavigationNeighbors) {
+
+	if l.neighbors == nil {
+		l.neighbors = map[uint64]NavigationNeighbors{}
+	}
+
+	for _, target := range []*NavigationTarget{&neighbors.Up, &neighbors.Down, &neighbors.Left, &neighbors.Right, &neighbors.Next, &neighbors.Prev} {
+		if target.Layout == "" {
+			target.hash = hashIDString(l.idScope(), target.ID)
+		} else {
+			target.hash = hashIDString(idHashSeed, target.ID)
+		}
+	}
+
+	l.neighbors[hashIDString(l.idScope(), id)] = neighbors
+
+}
+
+// navigateToNeighbor highlights the explicitly set neighbor of the currently highlighted element in the given
+// direction, returning whether it was able to.
+func (c *Context) navigateToNeighbor(direction NavigationInput) bool {
+
+	layout := c.highlightedElement.layout
+
+	neighbors, ok := layout.neighbors[c.highlightedElement.hash]
+	if !ok {
+		return false
+	}
+
+	target := neighbors.target(direction)
+	if target.ID == "" {
+		return false
+	}
+
+	targetLayout := layout
+	if target.Layout != "" {
+		targetLayout = nil
+		for _, l := range c.visibleLayouts {
+			if l.ID == target.Layout {
+				targetLayout = l
+				break
+			}
+		}
+	}
+
+	if targetLayout == nil || c.highlightingLocked(targetLayout) || !targetLayout.isVisible() {
+		return false
+	}
+
+	element := targetLayout.existingUIElements.Data[target.hash]
+	if element == nil || !element.wasDrawn || !element.drawable.highlightable() {
+		return false
+	}
+
+	c.highlightedElement = element
+	return true
+
+}
@@ -0,0 +1,285 @@
+package gooey
+
+import "testing"
+
+// candidatesAt returns NavigationCandidates for 32x32 UI elements at the given positions, in order.
+func candidatesAt(layout *Layout, positions ...Vector2) []NavigationCandidate {
+	candidates := make([]NavigationCandidate, len(positions))
+	for i, p := range positions {
+		candidates[i] = NavigationCandidate{Layout: layout, Rect: Rect{p.X, p.Y, 32, 32}, Index: i}
+	}
+	return candidates
+}
+
+func TestConeNavigationStrategy(t *testing.T) {
+
+	// A 3x3 grid of elements with a gap of 8 pixels between them:
+	// 0 1 2
+	// 3 4 5
+	// 6 7 8
+	grid := candidatesAt(nil,
+		Vector2{0, 0}, Vector2{40, 0}, Vector2{80, 0},
+		Vector2{0, 40}, Vector2{40, 40}, Vector2{80, 40},
+		Vector2{0, 80}, Vector2{40, 80}, Vector2{80, 80},
+	)
+
+	// An element directly to the right, and a closer one that's off to the side
+	staggered := candidatesAt(nil, Vector2{0, 0}, Vector2{200, 0}, Vector2{50, 60})
+
+	// An element that's only reachable at an angle
+	offset := candidatesAt(nil, Vector2{0, 0}, Vector2{100, 300})
+
+	tests := []struct {
+		name       string
+		candidates []NavigationCandidate
+		current    int
+		direction  NavigationInput
+		want       int
+	}{
+		{"right", grid, 4, NavigationInputRight, 5},
+		{"left", grid, 4, NavigationInputLeft, 3},
+		{"up", grid, 4, NavigationInputUp, 1},
+		{"down", grid, 4, NavigationInputDown, 7},
+		{"nearest in row", grid, 3, NavigationInputRight, 4},
+		{"stops at right edge", grid, 5, NavigationInputRight, -1},
+		{"stops at top edge", grid, 1, NavigationInputUp, -1},
+		{"prefers overlapping row", staggered, 0, NavigationInputRight, 1},
+		{"prefers overlapping column", staggered, 0, NavigationInputDown, 2},
+		{"outside of cone", offset, 0, NavigationInputRight, -1},
+		{"inside of cone", offset, 0, NavigationInputDown, 1},
+		{"next in reading order", grid, 2, NavigationInputNext, 3},
+		{"next wraps around", grid, 8, NavigationInputNext, 0},
+		{"previous wraps around", grid, 0, NavigationInputPrev, 8},
+		{"accept doesn't navigate", grid, 4, NavigationInputAccept, -1},
+	}
+
+	strategy := NewConeNavigationStrategy()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := strategy.Navigate(test.current, test.direction, test.candidates); got != test.want {
+				t.Errorf("Navigate() = %d, want %d", got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestConeNavigationStrategyDiagonal(t *testing.T) {
+
+	// 0 1 2
+	// 3 4 5
+	// 6 7 8
+	grid := candidatesAt(nil,
+		Vector2{0, 0}, Vector2{40, 0}, Vector2{80, 0},
+		Vector2{0, 40}, Vector2{40, 40}, Vector2{80, 40},
+		Vector2{0, 80}, Vector2{40, 80}, Vector2{80, 80},
+	)
+
+	tests := []struct {
+		name      string
+		current   int
+		direction Vector2
+		want      int
+	}{
+		{"down-right", 4, Vector2{1, 1}, 8},
+		{"up-left", 4, Vector2{-1, -1}, 0},
+		{"up-right", 4, Vector2{1, -1}, 2},
+		{"down-left", 4, Vector2{-1, 1}, 6},
+		{"corner", 8, Vector2{1, 1}, -1},
+		{"from edge", 3, Vector2{1, -1}, 1},
+	}
+
+	strategy := NewConeNavigationStrategy()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := strategy.NavigateDiagonal(test.current, test.direction, grid); got != test.want {
+				t.Errorf("NavigateDiagonal() = %d, want %d", got, test.want)
+			}
+		})
+	}
+
+	// Diagonal directions that have nothing in them fall back to the stick's dominant direction.
+	fallback := diagonalStrategy{DiagonalNavigationStrategy: strategy, direction: Vector2{1, 1}.Unit()}
+	if got := fallback.Navigate(5, NavigationInputDown, grid); got != 8 {
+		t.Errorf("diagonalStrategy.Navigate() = %d, want 8", got)
+	}
+
+}
+
+func TestGridNavigationStrategy(t *testing.T) {
+
+	ctx := newTestContext()
+
+	rows := ctx.NewLayout("rows", 0, 0, 120, 80)
+	rows.SetArranger(ArrangerGrid{ElementCount: 3})
+
+	columns := ctx.NewLayout("columns", 0, 0, 120, 80)
+	columns.SetArranger(ArrangerGrid{ElementCount: 2, Direction: ArrangeDirectionColumn})
+
+	other := ctx.NewLayout("other", 200, 0, 40, 40)
+
+	// Element indices, which GridNavigationStrategy goes by, rather than the candidates' Rects:
+	// 0 1 2
+	// 3 4 5
+	full := candidatesAt(rows, Vector2{0, 0}, Vector2{40, 0}, Vector2{80, 0}, Vector2{0, 40}, Vector2{40, 40}, Vector2{80, 40})
+
+	// The same, but with element 1 disabled, and so not a candidate
+	gap := []NavigationCandidate{full[0], full[2], full[3], full[4], full[5]}
+
+	// The same, with another Layout's element to the right of the grid
+	beside := append(append([]NavigationCandidate{}, full...), NavigationCandidate{Layout: other, Rect: Rect{200, 0, 32, 32}})
+
+	// Column-major element indices:
+	// 0 2 4
+	// 1 3 5
+	columnMajor := candidatesAt(columns, Vector2{0, 0}, Vector2{0, 40}, Vector2{40, 0}, Vector2{40, 40}, Vector2{80, 0}, Vector2{80, 40})
+
+	tests := []struct {
+		name       string
+		strategy   GridNavigationStrategy
+		candidates []NavigationCandidate
+		current    int
+		direction  NavigationInput
+		want       int
+	}{
+		{"right", NewGridNavigationStrategy(), full, 0, NavigationInputRight, 1},
+		{"down", NewGridNavigationStrategy(), full, 1, NavigationInputDown, 4},
+		{"up", NewGridNavigationStrategy(), full, 5, NavigationInputUp, 2},
+		{"stops at edge", NewGridNavigationStrategy(), full, 2, NavigationInputRight, -1},
+		{"wraps horizontally", NewGridNavigationStrategy().WithWrapHorizontal(true), full, 2, NavigationInputRight, 0},
+		{"doesn't wrap the other axis", NewGridNavigationStrategy().WithWrapHorizontal(true), full, 4, NavigationInputDown, -1},
+		{"wraps vertically", NewGridNavigationStrategy().WithWrapVertical(true), full, 4, NavigationInputDown, 1},
+		{"skips missing cells", NewGridNavigationStrategy(), gap, 0, NavigationInputRight, 1},
+		{"leaves for other Layouts", NewGridNavigationStrategy(), beside, 2, NavigationInputRight, 6},
+		{"next", NewGridNavigationStrategy(), full, 2, NavigationInputNext, 3},
+		{"next wraps around", NewGridNavigationStrategy(), full, 5, NavigationInputNext, 0},
+		{"previous wraps around", NewGridNavigationStrategy(), full, 0, NavigationInputPrev, 5},
+		{"column-major right", NewGridNavigationStrategy(), columnMajor, 0, NavigationInputRight, 2},
+		{"column-major down", NewGridNavigationStrategy(), columnMajor, 2, NavigationInputDown, 3},
+		{"column-major stops at edge", NewGridNavigationStrategy(), columnMajor, 3, NavigationInputDown, -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.strategy.Navigate(test.current, test.direction, test.candidates); got != test.want {
+				t.Errorf("Navigate() = %d, want %d", got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestEdgePolicies(t *testing.T) {
+
+	// Two Layouts side by side, each with two UI elements stacked vertically.
+	tests := []struct {
+		name      string
+		edges     func(sidebar, content *Layout)
+		from      string
+		direction NavigationInput
+		want      string
+	}{
+		{"open", func(l, _ *Layout) { l.SetEdgePolicy(EdgePolicyOpen) }, "sidebar/a", NavigationInputRight, "content/a"},
+		{"stop", func(l, _ *Layout) { l.SetEdgePolicy(EdgePolicyStop) }, "sidebar/a", NavigationInputRight, "sidebar/a"},
+		{"stop moves within Layout", func(l, _ *Layout) { l.SetEdgePolicy(EdgePolicyStop) }, "sidebar/a", NavigationInputDown, "sidebar/b"},
+		{"wrap", func(l, _ *Layout) { l.SetEdgePolicy(EdgePolicyWrap) }, "sidebar/b", NavigationInputDown, "sidebar/a"},
+		{"jump", func(l, _ *Layout) {
+			l.SetEdgePolicy(EdgePolicyStop)
+			l.Edges.Right = LayoutEdge{Policy: EdgePolicyJump, Layout: "content"}
+		}, "sidebar/b", NavigationInputRight, "content/b"},
+		{"jump to locked Layout", func(l, content *Layout) {
+			l.SetEdgePolicy(EdgePolicyStop)
+			l.Edges.Right = LayoutEdge{Policy: EdgePolicyJump, Layout: "content"}
+			content.HighlightingLocked = true
+		}, "sidebar/b", NavigationInputRight, "sidebar/b"},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			ctx := newTestContext()
+
+			for frame := 0; frame < 2; frame++ {
+
+				settings := UpdateSettings{}
+				if frame == 1 {
+					switch test.direction {
+					case NavigationInputRight:
+						settings.RightInput = true
+					case NavigationInputDown:
+						settings.DownInput = true
+					}
+				}
+
+				testFrame(ctx, func() {
+
+					sidebar := ctx.NewLayout("sidebar", 0, 0, 40, 80)
+					content := ctx.NewLayout("content", 100, 0, 40, 80)
+					sidebar.SetArranger(ArrangerGrid{ElementCount: 1})
+					content.SetArranger(ArrangerGrid{ElementCount: 1})
+					test.edges(sidebar, content)
+
+					for _, l := range []*Layout{sidebar, content} {
+						NewUIWidget(testWidget{}).AddTo(l, "a")
+						NewUIWidget(testWidget{}).AddTo(l, "b")
+					}
+
+					if frame == 0 {
+						ctx.Highlight(sidebar, test.from[len("sidebar/"):])
+					}
+
+				}, settings)
+
+			}
+
+			h := ctx.HighlightedUIElement()
+			if got := h.layout.ID + "/" + h.id; got != test.want {
+				t.Errorf("highlighted %s, want %s", got, test.want)
+			}
+
+		})
+
+	}
+
+}
+
+func TestNeighborsAreScoped(t *testing.T) {
+
+	ctx := newTestContext()
+
+	// Two copies of a component that use the same IDs in different scopes; only the second one has neighbors set,
+	// which skip over its middle element.
+	for frame := 0; frame < 2; frame++ {
+
+		testFrame(ctx, func() {
+
+			layout := ctx.NewLayout("menu", 0, 0, 40, 360)
+			layout.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+			for i := 0; i < 2; i++ {
+				layout.PushIDInt(i)
+				for _, id := range []string{"top", "middle", "bottom"} {
+					NewUIWidget(testWidget{}).AddTo(layout, id)
+				}
+				if i == 1 {
+					layout.SetNeighbors("top", NavigationNeighbors{Down: NavigationTarget{ID: "bottom"}})
+					if frame == 0 {
+						ctx.Highlight(layout, "top")
+					}
+				}
+				layout.PopID()
+			}
+
+		}, UpdateSettings{DownInput: frame == 1})
+
+	}
+
+	want := hashIDString(hashIDInt(idHashSeed, 1), "bottom")
+	if h := ctx.HighlightedUIElement(); h.hash != want {
+		t.Errorf("highlighted %s (%x), want the second component's bottom element (%x)", h.id, h.hash, want)
+	}
+
+}
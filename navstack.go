@@ -0,0 +1,155 @@
+package gooey
+
+import "slices"
+
+// NavigationEntry is an entry in a Context's navigation stack, representing a submenu (or other screen) made up of one or more Layouts.
+type NavigationEntry struct {
+	Layouts []*Layout // The Layouts making up the submenu.
+
+	// OnCancel, if set, is called when the player who opened the submenu presses cancel input while the entry is at the
+	// top of the navigation stack. Return false to refuse to close the submenu (e.g. to show a confirmation dialog first);
+	// otherwise, the entry is popped.
+	OnCancel func() bool
+
+	opener *uiElementInstance
+	player *playerState // The player that opened the submenu
+}
+
+// PushNavigation pushes a submenu onto the default Context's navigation stack. See Context.PushNavigation().
+func PushNavigation(entry NavigationEntry) {
+	defaultContext.PushNavigation(entry)
+}
+
+// PushNavigation pushes a submenu onto the Context's navigation stack. While the entry is at the top of the stack, only
+// UI elements in its Layouts can be highlighted (as long as those Layouts' highlighting isn't locked). Once it's
+// popped, either by the player who opened it pressing cancel input or by calling PopNavigation(), the UI element that
+// opened the submenu (the highlighted UI element, or the UI element pressed this frame) is highlighted again for that
+// player:
+//
+//	if gooey.NewUIButton().WithText("Options").AddTo(menu, "options") {
+//		ctx.PushNavigation(gooey.NavigationEntry{Layouts: []*gooey.Layout{optionsLayout}})
+//	}
+func (c *Context) PushNavigation(entry NavigationEntry) {
+
+	entry.player = c.playerState
+	entry.opener = c.highlightedElement
+	if entry.opener == nil {
+		entry.opener = c.lastPressed
+	}
+
+	c.navigationStack = append(c.navigationStack, &entry)
+
+	for _, p := range c.players {
+		if p.highlightedElement != nil && c.highlightingLocked(p.highlightedElement.layout) {
+			p.highlightedElement = nil
+		}
+	}
+
+}
+
+// PushLayouts pushes a submenu consisting of the given Layouts onto the default Context's navigation stack.
+func PushLayouts(layouts ...*Layout) {
+	defaultContext.PushLayouts(layouts...)
+}
+
+// PushLayouts pushes a submenu consisting of the given Layouts onto the Context's navigation stack. See Context.PushNavigation().
+func (c *Context) PushLayouts(layouts ...*Layout) {
+	c.PushNavigation(NavigationEntry{Layouts: layouts})
+}
+
+// PushPage pushes a submenu consisting of the given Page's Layouts onto the default Context's navigation stack.
+func PushPage(page *Page) {
+	defaultContext.PushPage(page)
+}
+
+// PushPage pushes a submenu consisting of the given Page's Layouts onto the Context's navigation stack.
+// The Page continues to control which of its Layouts are locked. See Context.PushNavigation().
+func (c *Context) PushPage(page *Page) {
+	c.PushNavigation(NavigationEntry{Layouts: page.Layouts})
+}
+
+// PopNavigation pops the top submenu off of the default Context's navigation stack. See Context.PopNavigation().
+func PopNavigation() bool {
+	return defaultContext.PopNavigation()
+}
+
+// PopNavigation pops the top submenu off of the Context's navigation stack, re-highlighting the UI element that opened
+// the submenu. Unlike pressing cancel input, this doesn't call the entry's OnCancel function, and can be called for any
+// player's submenu. Returns false if the navigation stack is empty.
+func (c *Context) PopNavigation() bool {
+
+	if len(c.navigationStack) == 0 {
+		return false
+	}
+
+	entry := c.navigationStack[len(c.navigationStack)-1]
+	c.navigationStack[len(c.navigationStack)-1] = nil
+	c.navigationStack = c.navigationStack[:len(c.navigationStack)-1]
+
+	restored := false
+
+	if opener := entry.opener; opener != nil && opener.layout.existingUIElements.Contains(opener.hash) {
+		entry.player.remember(opener, c.rememberFrame)
+		// Don't highlight the opener if the mouse or touch is being used; it's restored when directional input is used again.
+		if !entry.player.usingMouse && !entry.player.usingTouch {
+			entry.player.highlightedElement = opener
+			restored = true
+		}
+	}
+
+	for _, p := range c.players {
+		if restored && p == entry.player {
+			continue
+		}
+		if p.highlightedElement != nil && (c.highlightingLocked(p.highlightedElement.layout) || slices.Contains(entry.Layouts, p.highlightedElement.layout)) {
+			p.highlightedElement = nil
+		}
+	}
+
+	return true
+
+}
+
+// NavigationDepth returns the number of submenus on the default Context's navigation stack.
+func NavigationDepth() int {
+	return defaultContext.NavigationDepth()
+}
+
+// NavigationDepth returns the number of submenus on the Context's navigation stack.
+func (c *Context) NavigationDepth() int {
+	return len(c.navigationStack)
+}
+
+// cancelNavigation pops the top submenu off of the navigation stack in response to the active player's cancel input,
+// unless the submenu was opened by another player, or its OnCancel function refuses.
+func (c *Context) cancelNavigation() {
+
+	if len(c.navigationStack) == 0 {
+		return
+	}
+
+	top := c.navigationStack[len(c.navigationStack)-1]
+
+	if top.player != c.playerState || (top.OnCancel != nil && !top.OnCancel()) {
+		return
+	}
+
+	c.PopNavigation()
+
+}
+
+// highlightingLocked returns if UI elements in the given Layout can't be highlighted, either because the Layout's
+// highlighting is locked, or because it isn't part of the submenu at the top of the navigation stack.
+func (c *Context) highlightingLocked(l *Layout) bool {
+
+	if l.HighlightingLocked {
+		return true
+	}
+
+	if len(c.navigationStack) == 0 {
+		return false
+	}
+
+	return !slices.Contains(c.navigationStack[len(c.navigationStack)-1].Layouts, l)
+
+}
@@ -0,0 +1,98 @@
+package gooey
+
+import "testing"
+
+func TestNavigationLocksLaterLayouts(t *testing.T) {
+
+	ctx := newTestContext()
+
+	for frame := 0; frame < 5; frame++ {
+
+		testFrame(ctx, func() {
+
+			main := ctx.NewLayout("main", 0, 0, 40, 40)
+			NewUIWidget(testWidget{}).AddTo(main, "open")
+
+			if frame == 0 {
+				ctx.Highlight(main, "open")
+			}
+
+			options := ctx.NewLayout("options", 100, 0, 40, 80)
+			options.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+			if frame == 1 {
+				ctx.PushNavigation(NavigationEntry{Layouts: []*Layout{options}})
+			}
+
+			NewUIWidget(testWidget{}).AddTo(options, "a")
+			NewUIWidget(testWidget{}).AddTo(options, "b")
+
+			// A Layout that's first drawn after the submenu was pushed, right below it
+			if frame >= 1 {
+				NewUIWidget(testWidget{}).AddTo(ctx.NewLayout("late", 100, 80, 40, 40), "button")
+			}
+
+		}, UpdateSettings{DownInput: frame == 2 || frame == 4})
+
+		if h := ctx.HighlightedUIElement(); h != nil && h.layout.ID == "late" {
+			t.Fatalf("highlighted %v in frame %d, which isn't part of the submenu", h.ref(), frame)
+		}
+
+	}
+
+	if h := ctx.HighlightedUIElement(); h == nil || h.layout.ID != "options" || h.id != "b" {
+		t.Errorf("highlighted %v, want options/b", h.ref())
+	}
+
+}
+
+func TestNavigationCancelByOpener(t *testing.T) {
+
+	ctx := newTestContext()
+
+	// frame runs a frame with the given players' input, pushing a submenu for player 0 if push is true.
+	frame := func(push bool, players ...UpdateSettings) {
+
+		testFrame(ctx, func() {
+
+			main := ctx.NewLayout("main", 0, 0, 40, 80)
+			main.SetArranger(ArrangerGrid{ElementCount: 1})
+			NewUIWidget(testWidget{}).AddTo(main, "open")
+			NewUIWidget(testWidget{}).AddTo(main, "other")
+
+			options := ctx.NewLayout("options", 100, 0, 40, 40)
+			NewUIWidget(testWidget{}).AddTo(options, "a")
+
+			if push {
+				ctx.PushNavigation(NavigationEntry{Layouts: []*Layout{options}})
+			}
+
+		}, players...)
+
+	}
+
+	frame(false, UpdateSettings{}, UpdateSettings{})
+	frame(true, UpdateSettings{}, UpdateSettings{})
+
+	if depth := ctx.NavigationDepth(); depth != 1 {
+		t.Fatalf("navigation depth is %d after pushing, want 1", depth)
+	}
+
+	frame(false, UpdateSettings{}, UpdateSettings{CancelInput: true})
+
+	if depth := ctx.NavigationDepth(); depth != 1 {
+		t.Errorf("navigation depth is %d after another player pressed cancel, want 1", depth)
+	}
+
+	frame(false, UpdateSettings{}, UpdateSettings{})
+	frame(false, UpdateSettings{CancelInput: true}, UpdateSettings{})
+
+	if depth := ctx.NavigationDepth(); depth != 0 {
+		t.Errorf("navigation depth is %d after the opening player pressed cancel, want 0", depth)
+	}
+
+	if h := ctx.HighlightedUIElement(); h == nil || h.layout.ID != "main" || h.id != "open" {
+		t.Errorf("highlighted %v after cancelling, want main/open", h.ref())
+	}
+
+}
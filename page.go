@@ -41,10 +41,17 @@ func (p *Page) MakeActive(l *Layout) {
 	for i, other := range p.Layouts {
 		if other == l {
 			l.HighlightingLocked = false
+			p.activeIndex = i
 		} else {
 			other.HighlightingLocked = true
 		}
-		p.activeIndex = i
+	}
+
+	// Restore the Layout's previously highlighted (or default) UI element at the end of the frame, once it's known
+	// whether it's been drawn.
+	if c := l.context; c.highlightedElement == nil || c.highlightedElement.layout != l {
+		c.pendingHighlightLayout = l
+		c.pendingRestore = true
 	}
 
 }
@@ -57,7 +64,6 @@ func (p *Page) Advance(advance int) {
 		if p.activeIndex != next {
 			p.activeIndex = next
 			p.MakeActive(p.Layouts[next])
-			highlightedElement = nil
 		}
 	}
 
@@ -0,0 +1,73 @@
+package gooey
+
+import "testing"
+
+func TestPageMakeActiveRestoresHighlight(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		defaultID   string
+		disableLast bool // Whether the UI element highlighted before switching Pages is disabled when switching back
+		noRemember  bool
+		want        string
+	}{
+		{"remembered", "", false, false, "b"},
+		{"remembered over default", "c", false, false, "b"},
+		{"remembered element disabled", "", true, false, "a"},
+		{"earlier remembered element over default", "c", true, false, "a"},
+		{"default", "c", false, true, "c"},
+		{"no default", "", false, true, "a"},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			ctx := newTestContext()
+
+			first := ctx.NewLayout("first", 0, 0, 40, 120)
+			second := ctx.NewLayout("second", 100, 0, 40, 120)
+			page := NewPage(first, second)
+			disabled := false
+
+			// frame runs a frame, calling the given function before drawing the Layouts, as games switching Pages usually do.
+			frame := func(settings UpdateSettings, update func()) {
+
+				testFrame(ctx, func() {
+
+					if update != nil {
+						update()
+					}
+
+					for _, l := range []*Layout{ctx.NewLayout("first", 0, 0, 40, 120), ctx.NewLayout("second", 100, 0, 40, 120)} {
+						l.SetArranger(ArrangerGrid{ElementCount: 1})
+						l.DefaultHighlightID = test.defaultID
+						NewUIWidget(testWidget{}).AddTo(l, "a")
+						NewUIWidget(testWidget{disabled: l == first && disabled}).AddTo(l, "b")
+						NewUIWidget(testWidget{}).AddTo(l, "c")
+					}
+
+				}, settings)
+
+			}
+
+			frame(UpdateSettings{}, func() { ctx.Highlight(first, "a") })
+			frame(UpdateSettings{DownInput: true}, nil)
+			frame(UpdateSettings{}, func() { page.MakeActive(second) })
+
+			if h := ctx.HighlightedUIElement(); h == nil || h.layout != second {
+				t.Fatal("highlight didn't move to the active Page")
+			}
+
+			disabled = test.disableLast
+			frame(UpdateSettings{NoRememberHighlighting: test.noRemember}, func() { page.MakeActive(first) })
+
+			if h := ctx.HighlightedUIElement(); h == nil || h.layout != first || h.id != test.want {
+				t.Errorf("highlighted %v, want first/%s", h, test.want)
+			}
+
+		})
+
+	}
+
+}
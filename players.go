@@ -0,0 +1,180 @@
+package gooey
+
+import "time"
+
+// playerState holds the highlighting and input state for a single player. The Context embeds the active player's
+// playerState, switching it while handling each player's input (see BeginPlayers()).
+type playerState struct {
+	id int
+
+	queuedInput         int
+	analogDirection     Vector2 // The analog stick's direction when snapped to a diagonal; see UpdateSettings.AnalogEightWay
+	prevQueuedInput     int
+	repeatingMouseClick bool
+	justClicked         bool
+	prevMouseClick      bool
+	usingMouse          bool
+	usingTouch          bool
+
+	highlightedElement          *uiElementInstance
+	pendingHighlightLayout      *Layout // Set by Highlight() for UI elements that haven't been drawn yet
+	pendingHighlightHash        uint64
+	pendingRestore              bool               // Set by Page.MakeActive() to restore the pending Layout's remembered or default UI element instead
+	highlightCleared            bool               // Set by ClearHighlight() to stop a default UI element from being highlighted
+	reportedHighlight           *uiElementInstance // The highlighted UI element as of the last highlight events
+	lastPressed                 *uiElementInstance // The last UI element pressed this frame
+	editingElement              *uiElementInstance // The UI element in edit mode, if any (see EditModeAccept)
+	highlightControlInitialTime time.Time
+	highlightControlStartTime   time.Time
+	highlightRepeatCount        int // How many times the held highlight control input has repeated
+	updateSettings              UpdateSettings
+
+	rememberCache []rememberEntry
+}
+
+func newPlayerState(id int) *playerState {
+	return &playerState{
+		id:         id,
+		usingMouse: true,
+	}
+}
+
+// setPlayerCount adds or removes players so that the Context has the given number of them.
+func (c *Context) setPlayerCount(count int) {
+
+	for len(c.players) < count {
+		c.players = append(c.players, newPlayerState(len(c.players)))
+	}
+
+	if len(c.players) > count {
+		clear(c.players[count:])
+		c.players = c.players[:count]
+	}
+
+}
+
+// highlightingPlayer returns the player whose input the given UI element instance should respond to while it's drawn:
+// of the players highlighting it, the first one pressing input this frame, or otherwise the first one. Returns nil if
+// no player is highlighting it.
+func (c *Context) highlightingPlayer(inst *uiElementInstance) *playerState {
+
+	var found *playerState
+
+	for _, p := range c.players {
+		if p.highlightedElement == inst {
+			if p.queuedInput != queuedInputNone || p.updateSettings.AcceptInput {
+				return p
+			}
+			if found == nil {
+				found = p
+			}
+		}
+	}
+
+	return found
+
+}
+
+// PlayerCount returns the number of players in the default Context. See Context.BeginPlayers().
+func PlayerCount() int {
+	return defaultContext.PlayerCount()
+}
+
+// PlayerCount returns the number of players in the Context (the number of UpdateSettings passed to the last
+// BeginPlayers() call, or 1 if Begin() was used).
+func (c *Context) PlayerCount() int {
+	return len(c.players)
+}
+
+// ActivePlayer returns the ID of the active player in the default Context. See Context.ActivePlayer().
+func ActivePlayer() int {
+	return defaultContext.ActivePlayer()
+}
+
+// ActivePlayer returns the ID of the player the Context's highlighting functions currently act on. This is the primary
+// player (0), except inside AsPlayer(), while drawing a UI element highlighted by another player, and in callbacks
+// (like Context.OnHighlightChanged or ElementCallbacks.OnPressed) called for another player.
+func (c *Context) ActivePlayer() int {
+	return c.playerState.id
+}
+
+// AsPlayer calls the given function with the given player active in the default Context. See Context.AsPlayer().
+func AsPlayer(player int, f func()) {
+	defaultContext.AsPlayer(player, f)
+}
+
+// AsPlayer calls the given function with the given player active, so that the Context's highlighting functions
+// (Highlight(), ClearHighlight(), IsHighlighted(), HighlightedUIElement(), PushNavigation(), etc.) act on that player's
+// highlight instead of the primary player's. The function isn't called if the player doesn't exist:
+//
+//	ctx.AsPlayer(1, func() {
+//		ctx.Highlight(characterSelect, "p2-default")
+//	})
+func (c *Context) AsPlayer(player int, f func()) {
+
+	if player < 0 || player >= len(c.players) {
+		return
+	}
+
+	prev := c.playerState
+	c.playerState = c.players[player]
+	f()
+	c.playerState = prev
+
+}
+
+// PlayerHighlight returns the UI element highlighted by the given player in the default Context.
+func PlayerHighlight(player int) ElementRef {
+	return defaultContext.PlayerHighlight(player)
+}
+
+// PlayerHighlight returns the UI element highlighted by the given player in the Context, which is zero if the player
+// isn't highlighting anything or doesn't exist.
+func (c *Context) PlayerHighlight(player int) ElementRef {
+	if player < 0 || player >= len(c.players) {
+		return ElementRef{}
+	}
+	return c.players[player].highlightedElement.ref()
+}
+
+// LastPressedPlayer returns the ID of the player that pressed the last UI element pressed this frame in the default Context.
+func LastPressedPlayer() int {
+	return defaultContext.LastPressedPlayer()
+}
+
+// LastPressedPlayer returns the ID of the player that pressed the last UI element pressed this frame in the Context,
+// or -1 if nothing's been pressed. Call it right after a UIButton reports being pressed to find out who pressed it:
+//
+//	if gooey.NewUIButton().AddTo(characterSelect, "knight") {
+//		chosen[ctx.LastPressedPlayer()] = "knight"
+//	}
+func (c *Context) LastPressedPlayer() int {
+	return c.lastPressedPlayer
+}
+
+// Player returns the ID of the player whose input the UI element being drawn responds to: the player highlighting it
+// (see Context.BeginPlayers()), or the active player if no player is.
+func (dc *DrawCall) Player() int {
+	return dc.player
+}
+
+// HighlightingPlayers returns the IDs of all of the players highlighting the UI element being drawn.
+func (dc *DrawCall) HighlightingPlayers() []int {
+	players := []int{}
+	for _, p := range dc.Context().players {
+		if p.highlightedElement == dc.Instance {
+			players = append(players, p.id)
+		}
+	}
+	return players
+}
+
+// HighlightColor returns the color to draw the UI element being drawn with when it's highlighted: the highlighting
+// player's color from Context.PlayerHighlightColors if one is set, or the given color otherwise.
+func (dc *DrawCall) HighlightColor(color Color) Color {
+	colors := dc.Context().PlayerHighlightColors
+	if dc.isHighlighted && dc.player < len(colors) && !colors[dc.player].IsZero() {
+		return colors[dc.player]
+	}
+	return color
+}
@@ -0,0 +1,95 @@
+package gooey
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPlayersRememberHighlightsSeparately(t *testing.T) {
+
+	ctx := newTestContext()
+
+	// Both players move from the left Layout to the right one and back, which restores each player's own highlight.
+	inputs := []UpdateSettings{{}, {RightInput: true}, {}, {LeftInput: true}}
+
+	for frame, input := range inputs {
+
+		testFrame(ctx, func() {
+
+			left := ctx.NewLayout("left", 0, 0, 40, 80)
+			left.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+			left.SetEdgePolicy(EdgePolicyStop)
+			left.Edges.Right = LayoutEdge{Policy: EdgePolicyJump, Layout: "right"}
+			NewUIWidget(testWidget{}).AddTo(left, "a")
+			NewUIWidget(testWidget{}).AddTo(left, "b")
+
+			right := ctx.NewLayout("right", 100, 0, 40, 80)
+			right.SetEdgePolicy(EdgePolicyStop)
+			right.Edges.Left = LayoutEdge{Policy: EdgePolicyJump, Layout: "left"}
+			NewUIWidget(testWidget{}).AddTo(right, "x")
+
+			if frame == 0 {
+				ctx.Highlight(left, "b")
+				ctx.AsPlayer(1, func() { ctx.Highlight(left, "a") })
+			}
+
+		}, input, input)
+
+	}
+
+	for player, want := range []string{"b", "a"} {
+		if h := ctx.PlayerHighlight(player); h.Layout == nil || h.Layout.ID != "left" || h.ID != want {
+			t.Errorf("player %d highlighted %v, want left/%s", player, h, want)
+		}
+	}
+
+}
+
+func TestLayoutsScrollToEachPlayersHighlight(t *testing.T) {
+
+	ctx := newTestContext()
+
+	var first, second *Layout
+
+	list := func(id string, x float32) *Layout {
+		layout := ctx.NewLayout(id, x, 0, 100, 100)
+		layout.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+		layout.SetEdgePolicy(EdgePolicyStop)
+		for i := 0; i < 10; i++ {
+			NewUIWidget(testWidget{}).AddTo(layout, fmt.Sprint("item", i))
+		}
+		return layout
+	}
+
+	// The second player moves down to the last item of their list, while the primary player stays at the top of theirs.
+	for frame := 0; frame < 120; frame++ {
+
+		down := UpdateSettings{DownInput: frame > 0 && frame < 20 && frame%2 == 0}
+
+		testFrame(ctx, func() {
+
+			first = list("first", 0)
+			second = list("second", 200)
+
+			if frame == 0 {
+				ctx.Highlight(first, "item0")
+				ctx.AsPlayer(1, func() { ctx.Highlight(second, "item0") })
+			}
+
+		}, UpdateSettings{}, down)
+
+	}
+
+	if h := ctx.PlayerHighlight(1); h.Layout != second || h.ID != "item9" {
+		t.Fatalf("second player highlighted %v, want second/item9", h)
+	}
+
+	if lowest := second.Rect.H - 400; second.Offset.Y != lowest {
+		t.Errorf("second list's Offset is %v, want it scrolled to the bottom (%v)", second.Offset, lowest)
+	}
+
+	if !first.Offset.IsZero() {
+		t.Errorf("first list's Offset is %v, want it to stay at the top", first.Offset)
+	}
+
+}
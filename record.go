@@ -0,0 +1,440 @@
+package gooey
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// InputRecordingVersion is the version of the serialization format written by InputRecording.Save().
+const InputRecordingVersion = 1
+
+var inputRecordingMagic = [8]byte{'G', 'O', 'O', 'E', 'Y', 'R', 'E', 'C'}
+
+// InputFrameTouch is a single touch captured in an InputFrame.
+type InputFrameTouch struct {
+	ID   ebiten.TouchID
+	X, Y float32
+}
+
+// InputFrame is the input state captured for a single frame (a single call to Begin()).
+type InputFrame struct {
+	Time         time.Duration    // The time of the frame relative to the start of the recording.
+	Settings     UpdateSettings   // The UpdateSettings passed to Begin() (or the primary player's, for BeginPlayers()).
+	Players      []UpdateSettings // The UpdateSettings for any additional players passed to BeginPlayers().
+	CursorX      float32          // The cursor position, in screen coordinates.
+	CursorY      float32
+	WheelX       float32 // The mouse wheel's movement.
+	WheelY       float32
+	MouseButtons uint8 // Bitmask of pressed mouse buttons (1 << ebiten.MouseButton).
+	Touches      []InputFrameTouch
+	Actions      []Action // The Actions held in the Context's ActionMap, if one is set.
+}
+
+// InputRecording is a sequence of InputFrames captured from a Context using Context.StartRecording().
+// It can be saved, loaded, and replayed into a Context to reproduce a UI session exactly.
+type InputRecording struct {
+	Frames []InputFrame
+	start  time.Time
+}
+
+const recordedMouseButtonCount = 3
+
+func (r *InputRecording) record(frameTime time.Time, settings []UpdateSettings, provider InputProvider, actionMap *ActionMap) {
+
+	frame := InputFrame{
+		Time:     frameTime.Sub(r.start),
+		Settings: settings[0],
+	}
+
+	if len(settings) > 1 {
+		frame.Players = append(frame.Players, settings[1:]...)
+	}
+
+	frame.CursorX, frame.CursorY = provider.CursorPosition()
+	frame.WheelX, frame.WheelY = provider.Wheel()
+
+	for b := 0; b < recordedMouseButtonCount; b++ {
+		if provider.IsMouseButtonPressed(ebiten.MouseButton(b)) {
+			frame.MouseButtons |= 1 << b
+		}
+	}
+
+	for _, id := range provider.AppendTouchIDs(nil) {
+		x, y := provider.TouchPosition(id)
+		frame.Touches = append(frame.Touches, InputFrameTouch{ID: id, X: x, Y: y})
+	}
+
+	if actionMap != nil {
+		frame.Actions = actionMap.appendHeld(nil)
+	}
+
+	r.Frames = append(r.Frames, frame)
+
+}
+
+const (
+	recordFlagLeft = 1 << iota
+	recordFlagRight
+	recordFlagUp
+	recordFlagDown
+	recordFlagNext
+	recordFlagPrev
+	recordFlagAccept
+	recordFlagCancel
+	recordFlagUseMouse
+	recordFlagLeftMouseClick
+	recordFlagNoRememberHighlighting
+	recordFlagNoDefaultHighlightOption
+	recordFlagAnalogEightWay
+	recordFlagUseTouch
+)
+
+func (s UpdateSettings) recordFlags() uint32 {
+
+	flags := uint32(0)
+
+	set := func(value bool, flag uint32) {
+		if value {
+			flags |= flag
+		}
+	}
+
+	set(s.LeftInput, recordFlagLeft)
+	set(s.RightInput, recordFlagRight)
+	set(s.UpInput, recordFlagUp)
+	set(s.DownInput, recordFlagDown)
+	set(s.NextInput, recordFlagNext)
+	set(s.PrevInput, recordFlagPrev)
+	set(s.AcceptInput, recordFlagAccept)
+	set(s.CancelInput, recordFlagCancel)
+	set(s.UseMouse, recordFlagUseMouse)
+	set(s.LeftMouseClick, recordFlagLeftMouseClick)
+	set(s.NoRememberHighlighting, recordFlagNoRememberHighlighting)
+	set(s.NoDefaultHighlightOption, recordFlagNoDefaultHighlightOption)
+	set(s.AnalogEightWay, recordFlagAnalogEightWay)
+	set(s.UseTouch, recordFlagUseTouch)
+
+	return flags
+
+}
+
+func (s *UpdateSettings) setRecordFlags(flags uint32) {
+	s.LeftInput = flags&recordFlagLeft > 0
+	s.RightInput = flags&recordFlagRight > 0
+	s.UpInput = flags&recordFlagUp > 0
+	s.DownInput = flags&recordFlagDown > 0
+	s.NextInput = flags&recordFlagNext > 0
+	s.PrevInput = flags&recordFlagPrev > 0
+	s.AcceptInput = flags&recordFlagAccept > 0
+	s.CancelInput = flags&recordFlagCancel > 0
+	s.UseMouse = flags&recordFlagUseMouse > 0
+	s.LeftMouseClick = flags&recordFlagLeftMouseClick > 0
+	s.NoRememberHighlighting = flags&recordFlagNoRememberHighlighting > 0
+	s.NoDefaultHighlightOption = flags&recordFlagNoDefaultHighlightOption > 0
+	s.AnalogEightWay = flags&recordFlagAnalogEightWay > 0
+	s.UseTouch = flags&recordFlagUseTouch > 0
+}
+
+// Save writes the InputRecording to the given writer in gooey's versioned binary recording format.
+func (r *InputRecording) Save(w io.Writer) error {
+
+	bw := bufio.NewWriter(w)
+
+	write := func(data any) {
+		binary.Write(bw, binary.LittleEndian, data)
+	}
+
+	write(inputRecordingMagic)
+	write(uint16(InputRecordingVersion))
+	write(uint32(len(r.Frames)))
+
+	writeSettings := func(s UpdateSettings) {
+		write(s.recordFlags())
+		write(int64(s.HighlightControlRepeatInitialDelay))
+		write(int64(s.HighlightControlRepeatDelay))
+		write(int64(s.DeltaTime))
+		write([4]float32{s.AnalogX, s.AnalogY, s.AnalogDeadZone, s.AnalogMaxRepeatSpeed})
+	}
+
+	for _, f := range r.Frames {
+		write(int64(f.Time))
+		writeSettings(f.Settings)
+		write(uint8(len(f.Players)))
+		for _, s := range f.Players {
+			writeSettings(s)
+		}
+		write([4]float32{f.CursorX, f.CursorY, f.WheelX, f.WheelY})
+		write(f.MouseButtons)
+		write(uint16(len(f.Touches)))
+		for _, t := range f.Touches {
+			write(int32(t.ID))
+			write([2]float32{t.X, t.Y})
+		}
+		write(uint16(len(f.Actions)))
+		for _, a := range f.Actions {
+			write(uint16(len(a)))
+			write([]byte(a))
+		}
+	}
+
+	return bw.Flush()
+
+}
+
+// LoadInputRecording reads an InputRecording written by InputRecording.Save() from the given reader.
+func LoadInputRecording(r io.Reader) (*InputRecording, error) {
+
+	br := bufio.NewReader(r)
+
+	var err error
+
+	read := func(data any) {
+		if err == nil {
+			err = binary.Read(br, binary.LittleEndian, data)
+		}
+	}
+
+	magic := [8]byte{}
+	version := uint16(0)
+	frameCount := uint32(0)
+
+	read(&magic)
+	read(&version)
+	read(&frameCount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if magic != inputRecordingMagic {
+		return nil, errors.New("gooey: data is not a gooey input recording")
+	}
+
+	if version != InputRecordingVersion {
+		return nil, fmt.Errorf("gooey: unsupported input recording version %d", version)
+	}
+
+	readSettings := func() UpdateSettings {
+
+		var initialDelay, delay, deltaTime int64
+		var flags uint32
+		var analog [4]float32
+
+		read(&flags)
+		read(&initialDelay)
+		read(&delay)
+		read(&deltaTime)
+		read(&analog)
+
+		s := UpdateSettings{}
+		s.setRecordFlags(flags)
+		s.HighlightControlRepeatInitialDelay = time.Duration(initialDelay)
+		s.HighlightControlRepeatDelay = time.Duration(delay)
+		s.DeltaTime = time.Duration(deltaTime)
+		s.AnalogX, s.AnalogY = analog[0], analog[1]
+		s.AnalogDeadZone, s.AnalogMaxRepeatSpeed = analog[2], analog[3]
+		return s
+
+	}
+
+	rec := &InputRecording{}
+
+	for i := uint32(0); i < frameCount && err == nil; i++ {
+
+		var frameTime int64
+		var pointer [4]float32
+		var playerCount uint8
+		var touchCount, actionCount uint16
+
+		frame := InputFrame{}
+
+		read(&frameTime)
+		frame.Settings = readSettings()
+		read(&playerCount)
+		for p := uint8(0); p < playerCount && err == nil; p++ {
+			frame.Players = append(frame.Players, readSettings())
+		}
+		read(&pointer)
+		read(&frame.MouseButtons)
+		read(&touchCount)
+
+		for t := uint16(0); t < touchCount && err == nil; t++ {
+			var id int32
+			var pos [2]float32
+			read(&id)
+			read(&pos)
+			frame.Touches = append(frame.Touches, InputFrameTouch{ID: ebiten.TouchID(id), X: pos[0], Y: pos[1]})
+		}
+
+		read(&actionCount)
+
+		for a := uint16(0); a < actionCount && err == nil; a++ {
+			var length uint16
+			read(&length)
+			name := make([]byte, length)
+			read(name)
+			frame.Actions = append(frame.Actions, Action(name))
+		}
+
+		frame.Time = time.Duration(frameTime)
+		frame.CursorX, frame.CursorY, frame.WheelX, frame.WheelY = pointer[0], pointer[1], pointer[2], pointer[3]
+
+		rec.Frames = append(rec.Frames, frame)
+
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+
+}
+
+// inputReplay plays back an InputRecording; while replaying, it acts as the Context's InputProvider.
+type inputReplay struct {
+	recording *InputRecording
+	start     time.Time
+	index     int
+	frame     *InputFrame
+}
+
+func (r *inputReplay) next() (*InputFrame, bool) {
+	if r.index >= len(r.recording.Frames) {
+		return nil, false
+	}
+	r.frame = &r.recording.Frames[r.index]
+	r.index++
+	return r.frame, true
+}
+
+func (r *inputReplay) CursorPosition() (x, y float32) {
+	return r.frame.CursorX, r.frame.CursorY
+}
+
+func (r *inputReplay) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return button >= 0 && button < recordedMouseButtonCount && r.frame.MouseButtons&(1<<button) > 0
+}
+
+func (r *inputReplay) Wheel() (x, y float32) {
+	return r.frame.WheelX, r.frame.WheelY
+}
+
+func (r *inputReplay) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	for _, t := range r.frame.Touches {
+		touches = append(touches, t.ID)
+	}
+	return touches
+}
+
+func (r *inputReplay) TouchPosition(id ebiten.TouchID) (x, y float32) {
+	for _, t := range r.frame.Touches {
+		if t.ID == id {
+			return t.X, t.Y
+		}
+	}
+	return 0, 0
+}
+
+// Keys and gamepad buttons aren't recorded themselves; the ActionMap's Actions that they're bound to are, instead.
+
+func (r *inputReplay) IsKeyPressed(key ebiten.Key) bool {
+	return false
+}
+
+func (r *inputReplay) IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return false
+}
+
+// resetSessionState resets the Context's highlighting, input, and UI element states so that a recording
+// and its replay both begin from the same state.
+func (c *Context) resetSessionState() {
+	c.existingLayouts = c.existingLayouts[:0]
+	c.visibleLayouts = c.visibleLayouts[:0]
+	clear(c.layoutsFromStrings)
+	c.playerState = newPlayerState(0)
+	c.players = []*playerState{c.playerState}
+	c.lastPressedPlayer = -1
+	clear(c.navigationStack)
+	c.navigationStack = c.navigationStack[:0]
+	c.events = c.events[:0]
+	clear(c.touches)
+	c.touches = c.touches[:0]
+	c.mouseDrag = nil
+	c.rememberFrame = 0
+	if c.actionMap != nil {
+		c.actionMap.reset()
+	}
+}
+
+// StartRecording begins recording the input passed to the default Context. See Context.StartRecording().
+func StartRecording() {
+	defaultContext.StartRecording()
+}
+
+// StopRecording stops recording input for the default Context and returns the recording.
+func StopRecording() *InputRecording {
+	return defaultContext.StopRecording()
+}
+
+// StartRecording begins recording the input passed to the Context in each Begin() call (the UpdateSettings,
+// as well as the pointer state from the Context's InputProvider and the Actions held in its ActionMap).
+// Starting a recording resets the Context's Layouts, highlighting, and UI element states, so that replaying
+// the recording later starts from the same state. This should be called outside of a Begin() / End() pair.
+func (c *Context) StartRecording() {
+	c.resetSessionState()
+	c.recording = &InputRecording{start: c.frameTime}
+}
+
+// StopRecording stops recording input for the Context and returns the recording, or nil if the Context wasn't recording.
+func (c *Context) StopRecording() *InputRecording {
+	rec := c.recording
+	c.recording = nil
+	return rec
+}
+
+// IsRecording returns if the Context is currently recording input.
+func (c *Context) IsRecording() bool {
+	return c.recording != nil
+}
+
+// StartReplay begins replaying the given InputRecording into the default Context. See Context.StartReplay().
+func StartReplay(recording *InputRecording) {
+	defaultContext.StartReplay(recording)
+}
+
+// StopReplay stops replaying input into the default Context.
+func StopReplay() {
+	defaultContext.StopReplay()
+}
+
+// StartReplay begins replaying the given InputRecording into the Context. While replaying, each call to Begin()
+// ignores the UpdateSettings passed, the Context's InputProvider, and the InputSources bound in its ActionMap,
+// using the next recorded frame instead.
+// Once the recording runs out of frames, the replay stops automatically.
+// Like StartRecording(), this resets the Context's Layouts, highlighting, and UI element states, and should be
+// called outside of a Begin() / End() pair. Note that the same UI elements need to be drawn in the same order
+// as when the recording was made for the replay to produce the same result.
+func (c *Context) StartReplay(recording *InputRecording) {
+	c.resetSessionState()
+	c.replay = &inputReplay{
+		recording: recording,
+		start:     c.frameTime,
+	}
+}
+
+// StopReplay stops replaying input into the Context.
+func (c *Context) StopReplay() {
+	c.replay = nil
+}
+
+// IsReplaying returns if the Context is currently replaying an InputRecording.
+func (c *Context) IsReplaying() bool {
+	return c.replay != nil
+}
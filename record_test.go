@@ -0,0 +1,195 @@
+package gooey
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// recordingSession runs a short scripted UI session, returning a log of what happened in each frame.
+// If script is false, the session is driven by a replay instead, so the input passed to Begin() is ignored.
+func recordingSession(ctx *Context, provider *testInputProvider, script bool) []string {
+
+	log := []string{}
+
+	for frame := 0; frame < 40; frame++ {
+
+		settings := UpdateSettings{UseMouse: true}
+		provider.cursor = Vector2{}
+		provider.custom = false
+		clear(provider.buttons)
+		clear(provider.keys)
+
+		if script {
+			switch {
+			case frame >= 2 && frame < 4:
+				// Hold the down key, bound to ActionDown
+				provider.keys[ebiten.KeyDown] = true
+			case frame >= 8 && frame < 10:
+				settings.RightInput = true
+			case frame == 14:
+				// Hold the right mouse button, bound to ActionAccept, to press the highlighted button
+				provider.buttons[ebiten.MouseButtonRight] = true
+			case frame >= 20 && frame < 24:
+				// Click on the last button with the mouse
+				provider.cursor = Vector2{150, 100}
+				settings.LeftMouseClick = frame < 22
+			case frame == 30:
+				provider.custom = true
+			}
+		}
+
+		testFrame(ctx, func() {
+
+			layout := ctx.NewLayout("menu", 0, 0, 200, 120)
+			layout.SetArranger(ArrangerGrid{ElementCount: 2, ElementSize: Vector2{0, 60}})
+
+			for i := 0; i < 4; i++ {
+				NewUIButton().AddTo(layout, fmt.Sprint("button", i))
+			}
+
+		}, settings)
+
+		entry := fmt.Sprint(frame, ": ", ctx.HighlightedUIElement().ref().ID)
+		for _, e := range ctx.DrainEvents() {
+			entry += fmt.Sprint(" ", e.Type, ":", e.Element.ID)
+		}
+		if ctx.ActionTriggered("custom") {
+			entry += " custom"
+		}
+
+		log = append(log, entry)
+
+	}
+
+	return log
+
+}
+
+func TestRecordingReplaysDeterministically(t *testing.T) {
+
+	ctx := newTestContext()
+
+	provider := &testInputProvider{buttons: map[ebiten.MouseButton]bool{}, keys: map[ebiten.Key]bool{}}
+	ctx.SetInputProvider(provider)
+
+	ctx.SetActionMap(NewActionMap().
+		Bind(ActionDown, KeySource(ebiten.KeyDown)).
+		Bind(ActionAccept, MouseButtonSource(ebiten.MouseButtonRight)).
+		Bind("custom", InputSourceFunc(func() bool { return provider.custom })))
+
+	ctx.StartRecording()
+	recorded := recordingSession(ctx, provider, true)
+	recording := ctx.StopRecording()
+
+	buffer := &bytes.Buffer{}
+	if err := recording.Save(buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadInputRecording(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(loaded.Frames, recording.Frames) {
+		t.Fatal("loaded recording doesn't match the saved one")
+	}
+
+	ctx.StartReplay(loaded)
+	replayed := recordingSession(ctx, provider, false)
+
+	for i := range recorded {
+		if recorded[i] != replayed[i] {
+			t.Errorf("frame diverged during replay:\n\trecorded: %s\n\treplayed: %s", recorded[i], replayed[i])
+		}
+	}
+
+}
+
+func TestReplayStartsFromRecordedState(t *testing.T) {
+
+	ctx := newTestContext()
+
+	provider := &testInputProvider{cursor: Vector2{50, 50}}
+	ctx.SetInputProvider(provider)
+
+	var list *Layout
+
+	// session runs a few frames of a long list that's scrolled with the mouse wheel at first, returning the list's
+	// Offset in each frame.
+	session := func() []Vector2 {
+
+		offsets := []Vector2{}
+
+		for frame := 0; frame < 8; frame++ {
+
+			provider.wheel = Vector2{}
+			if frame < 4 {
+				provider.wheel.Y = -1
+			}
+
+			testFrame(ctx, func() {
+
+				list = ctx.NewLayout("list", 0, 0, 100, 100)
+				list.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+				for i := 0; i < 10; i++ {
+					NewUIWidget(testWidget{}).AddTo(list, fmt.Sprint("item", i))
+				}
+
+			}, UpdateSettings{UseMouse: true})
+
+			offsets = append(offsets, list.Offset)
+
+		}
+
+		return offsets
+
+	}
+
+	session()
+
+	ctx.StartRecording()
+	recorded := session()
+	recording := ctx.StopRecording()
+
+	if recorded[len(recorded)-1].Y == 0 {
+		t.Fatal("the list wasn't scrolled while recording")
+	}
+
+	// The list from the end of the recorded session isn't drawn anymore once the replay starts.
+	previous := list
+	previousOffset := previous.Offset
+
+	ctx.StartReplay(recording)
+	replayed := session()
+
+	if previous.Offset != previousOffset {
+		t.Errorf("the recorded session's list was scrolled from %v to %v by the replay", previousOffset, previous.Offset)
+	}
+
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("list scrolled differently during replay:\n\trecorded: %v\n\treplayed: %v", recorded, replayed)
+	}
+
+}
+
+func TestLoadInputRecordingRejectsUnknownVersions(t *testing.T) {
+
+	buffer := &bytes.Buffer{}
+	if err := (&InputRecording{}).Save(buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buffer.Bytes()
+	data[len(inputRecordingMagic)] = InputRecordingVersion + 1
+
+	if _, err := LoadInputRecording(bytes.NewReader(data)); err == nil {
+		t.Error("expected an error loading a recording with an unknown version")
+	}
+
+}
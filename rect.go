@@ -178,7 +178,7 @@ func (r Rect) ClampToRect(other Rect, padding float32) Rect {
 }
 
 func (r Rect) AlignToScreenbuffer(alignment Alignment, padding float32) Rect {
-	return r.AlignToImage(screenBuffer, alignment, padding)
+	return r.AlignToImage(defaultContext.screenBuffer, alignment, padding)
 }
 
 func (r Rect) AlignToImage(img *ebiten.Image, alignment Alignment, padding float32) Rect {
@@ -0,0 +1,360 @@
+package gooey
+
+import "math"
+
+const (
+	wheelScrollDistance  = 16   // How far one notch of the mouse wheel scrolls a Layout, before ScrollWheelScrollSpeed is applied
+	overscrollResistance = 0.5  // How much of a drag is applied while a Layout is scrolled past the ends of its contents
+	overscrollSpringBack = 0.25 // How much of the overscroll distance a released Layout springs back each (60 FPS) frame
+	minScrollVelocity    = 0.1  // The speed below which a Layout's scrolling momentum stops
+)
+
+// scrollBounds returns the lowest Offset the Layout can be scrolled to without overscrolling; the highest is always 0.
+// Returns false for an axis if the Layout's contents fit within its Rect on that axis.
+func (l *Layout) scrollBounds() (lowest Vector2, scrollX, scrollY bool) {
+	lowest = Vector2{l.Rect.W - l.contentRect.W, l.Rect.H - l.contentRect.H}
+	return lowest, lowest.X < 0, lowest.Y < 0
+}
+
+// scrollable returns if the Layout's contents extend past its Rect, so that it can be scrolled.
+func (l *Layout) scrollable() bool {
+	_, scrollX, scrollY := l.scrollBounds()
+	return scrollX || scrollY
+}
+
+// scrollBy moves the Layout's Offset by the given delta, clamped so that its contents stay within its Rect.
+func (l *Layout) scrollBy(delta Vector2) {
+
+	lowest, scrollX, scrollY := l.scrollBounds()
+
+	if scrollX {
+		l.Offset.X = clamp(l.Offset.X+delta.X, lowest.X, 0)
+		l.autoScrollCurrentSpeed.X = 0
+	}
+
+	if scrollY {
+		l.Offset.Y = clamp(l.Offset.Y+delta.Y, lowest.Y, 0)
+		l.autoScrollCurrentSpeed.Y = 0
+	}
+
+}
+
+// dragBy moves the Layout's Offset by the given delta as it's being dragged, allowing it to rubber-band up to
+// Layout.Overscroll past the ends of its contents.
+func (l *Layout) dragBy(delta Vector2) {
+
+	lowest, scrollX, scrollY := l.scrollBounds()
+
+	drag := func(offset, delta, lowest float32) float32 {
+		if offset > 0 || offset < lowest {
+			delta *= overscrollResistance
+		}
+		return clamp(offset+delta, lowest-l.Overscroll, l.Overscroll)
+	}
+
+	if scrollX {
+		l.Offset.X = drag(l.Offset.X, delta.X, lowest.X)
+		l.autoScrollCurrentSpeed.X = 0
+	}
+
+	if scrollY {
+		l.Offset.Y = drag(l.Offset.Y, delta.Y, lowest.Y)
+		l.autoScrollCurrentSpeed.Y = 0
+	}
+
+}
+
+// autoScrollTo scrolls the Layout towards the given highlighted UI element in it, accelerating up to its AutoScrollSpeed
+// until the UI element is comfortably onscreen.
+func (l *Layout) autoScrollTo(element *uiElementInstance, delta float32) {
+
+	scrollingX := false
+	scrollingY := false
+
+	if l.committedMaxRect.H > l.Rect.H {
+		scrollingY = true
+
+		centerScreenY := l.Rect.Y + (l.Rect.H / 2)
+		edgeSlop := l.Rect.H / 3
+
+		// Basically, if the element is small enough, then scroll the screen to put it wholly onscreen
+		// with some extra tolerance (i.e. some distance away from the edge)
+		downTooFar := element.currentRect.Bottom() > centerScreenY+edgeSlop
+		upTooFar := element.currentRect.Y < centerScreenY-edgeSlop
+
+		// If it's too big, then we just scroll it so its leading edge is onscreen
+		if element.currentRect.H > edgeSlop {
+			downTooFar = element.currentRect.Bottom() > l.Rect.Y+l.Rect.H
+			upTooFar = element.currentRect.Y < l.Rect.Y
+		}
+
+		if downTooFar {
+			l.autoScrollCurrentSpeed.Y -= l.AutoScrollAcceleration * delta
+		} else if upTooFar {
+			l.autoScrollCurrentSpeed.Y += l.AutoScrollAcceleration * delta
+		} else {
+
+			if l.autoScrollCurrentSpeed.Y >= l.AutoScrollAcceleration*delta {
+				l.autoScrollCurrentSpeed.Y -= l.AutoScrollAcceleration * delta
+			} else if l.autoScrollCurrentSpeed.Y <= -l.AutoScrollAcceleration*delta {
+				l.autoScrollCurrentSpeed.Y += l.AutoScrollAcceleration * delta
+			} else {
+				l.autoScrollCurrentSpeed.Y = 0
+			}
+
+		}
+
+	} else {
+		l.Offset.Y = 0
+	}
+
+	if l.committedMaxRect.W > l.Rect.W {
+		scrollingX = true
+
+		centerScreenX := l.Rect.X + (l.Rect.W / 2)
+		edgeSlop := l.Rect.W / 3
+
+		// Basically, if the element is small enough, then scroll the screen to put it wholly onscreen
+		// with some extra tolerance (i.e. some distance away from the edge)
+		rightTooFar := element.currentRect.Right() > centerScreenX+edgeSlop
+		leftTooFar := element.currentRect.X < centerScreenX-edgeSlop
+
+		// If it's too big, then we just scroll it so its leading edge is onscreen
+		if element.currentRect.W >= edgeSlop {
+			rightTooFar = element.currentRect.Right() > l.Rect.X+l.Rect.W
+			leftTooFar = element.currentRect.X < l.Rect.X
+		}
+
+		if rightTooFar {
+			l.autoScrollCurrentSpeed.X -= l.AutoScrollAcceleration * delta
+		} else if leftTooFar {
+			l.autoScrollCurrentSpeed.X += l.AutoScrollAcceleration * delta
+		} else {
+
+			if l.autoScrollCurrentSpeed.X >= l.AutoScrollAcceleration*delta {
+				l.autoScrollCurrentSpeed.X -= l.AutoScrollAcceleration * delta
+			} else if l.autoScrollCurrentSpeed.X <= -l.AutoScrollAcceleration*delta {
+				l.autoScrollCurrentSpeed.X += l.AutoScrollAcceleration * delta
+			} else {
+				l.autoScrollCurrentSpeed.X = 0
+			}
+
+		}
+
+	} else {
+		l.Offset.X = 0
+	}
+
+	if scrollingY {
+
+		l.autoScrollCurrentSpeed.Y = clamp(l.autoScrollCurrentSpeed.Y, -l.AutoScrollSpeed, l.AutoScrollSpeed)
+
+		ogScrollY := l.Offset.Y
+		l.Offset.Y = clamp(l.Offset.Y+l.autoScrollCurrentSpeed.Y*delta, -(l.committedMaxRect.H - l.Rect.H), 0)
+
+		// Scroll's the same as clamped; it hit a barrier, stop speed
+		if l.Offset.Y == ogScrollY {
+			l.autoScrollCurrentSpeed.Y = 0
+		}
+	}
+
+	if scrollingX {
+
+		l.autoScrollCurrentSpeed.X = clamp(l.autoScrollCurrentSpeed.X, -l.AutoScrollSpeed, l.AutoScrollSpeed)
+
+		ogScrollX := l.Offset.X
+		l.Offset.X = clamp(l.Offset.X+l.autoScrollCurrentSpeed.X*delta, -(l.committedMaxRect.W - l.Rect.W), 0)
+
+		// Scroll's the same as clamped; it hit a barrier, stop speed
+		if l.Offset.X == ogScrollX {
+			l.autoScrollCurrentSpeed.X = 0
+		}
+
+	}
+
+}
+
+// updateScrollMomentum moves a Layout that isn't being dragged according to its scrolling momentum, slowing it down
+// by its ScrollFriction, and springs it back if it's scrolled past the ends of its contents.
+func (l *Layout) updateScrollMomentum(delta float32) {
+
+	if l.dragged {
+		l.dragged = false
+		return
+	}
+
+	lowest, scrollX, scrollY := l.scrollBounds()
+
+	if !scrollX {
+		l.scrollVelocity.X = 0
+	}
+
+	if !scrollY {
+		l.scrollVelocity.Y = 0
+	}
+
+	if !l.scrollVelocity.IsZero() {
+
+		l.Offset = l.Offset.Add(l.scrollVelocity.Scale(delta))
+
+		friction := clamp(l.ScrollFriction, 0, 1)
+		l.scrollVelocity = l.scrollVelocity.Scale(float32(math.Pow(float64(friction), float64(delta))))
+
+		if l.scrollVelocity.Magnitude() < minScrollVelocity {
+			l.scrollVelocity = Vector2{}
+		}
+
+	}
+
+	springBack := 1 - float32(math.Pow(1-overscrollSpringBack, float64(delta)))
+
+	spring := func(offset, velocity, lowest float32) (float32, float32) {
+		target := clamp(offset, lowest, 0)
+		if offset == target {
+			return offset, velocity
+		}
+		offset = clamp(offset, lowest-l.Overscroll, l.Overscroll)
+		offset += (target - offset) * springBack
+		if math.Abs(float64(target-offset)) < 0.5 {
+			offset = target
+		}
+		return offset, 0
+	}
+
+	if scrollX {
+		l.Offset.X, l.scrollVelocity.X = spring(l.Offset.X, l.scrollVelocity.X, lowest.X)
+	}
+
+	if scrollY {
+		l.Offset.Y, l.scrollVelocity.Y = spring(l.Offset.Y, l.scrollVelocity.Y, lowest.Y)
+	}
+
+}
+
+// updateMouseDrag tracks the primary player's mouse button being held down, so that click-dragging can scroll Layouts.
+func (c *Context) updateMouseDrag() {
+
+	p := c.players[0]
+
+	if c.mouseDrag != nil && c.mouseDrag.Released {
+		c.mouseDrag = nil
+	}
+
+	if c.mouseDrag == nil {
+		if p.usingMouse && p.justClicked {
+			c.mouseDrag = &touchPoint{
+				Touch:   Touch{Start: c.cursor, Position: c.cursor, Previous: c.cursor},
+				started: true,
+			}
+		}
+		return
+	}
+
+	c.mouseDrag.started = false
+	c.mouseDrag.Previous = c.mouseDrag.Position
+	c.mouseDrag.Position = c.cursor
+	c.mouseDrag.Released = !p.usingMouse || !p.updateSettings.LeftMouseClick
+
+	if !c.mouseDrag.Dragging && c.mouseDrag.Position.DistanceTo(c.mouseDrag.Start) > TouchDragDistance {
+		c.mouseDrag.Dragging = true
+	}
+
+}
+
+// mouseDragScrolling returns if the mouse is being dragged to scroll a Layout.
+func (c *Context) mouseDragScrolling() bool {
+	return c.mouseDrag != nil && c.mouseDrag.scrolling != nil
+}
+
+// updateDragScrolling scrolls the Layouts being dragged by touches or the mouse that aren't claimed by UI elements,
+// giving them momentum when released.
+func (c *Context) updateDragScrolling(delta float32) {
+
+	drags := c.touches
+	if c.mouseDrag != nil {
+		drags = append(drags[:len(drags):len(drags)], c.mouseDrag)
+	}
+
+	for _, t := range drags {
+
+		if !t.Dragging || t.owner != nil {
+			continue
+		}
+
+		if t.scrolling == nil {
+			// Later Layouts are drawn on top, so they get the first chance to scroll.
+			for i := len(c.visibleLayouts) - 1; i >= 0; i-- {
+				if l := c.visibleLayouts[i]; !l.NoDragScrolling && l.scrollable() && t.Start.Inside(l.Rect) {
+					t.scrolling = l
+					break
+				}
+			}
+		}
+
+		if l := t.scrolling; l != nil && l.isVisible() {
+
+			if t.Released {
+				// Keep the momentum from the frames before the release
+				continue
+			}
+
+			move := t.Position.Sub(t.Previous)
+			l.dragBy(move)
+			l.dragged = true
+			l.userScrolled = true
+
+			// Smooth the velocity over a few frames, so that the release doesn't depend on the last frame alone
+			l.scrollVelocity = l.scrollVelocity.Scale(0.5).Add(move.Divide(delta).Scale(0.5))
+
+		}
+
+	}
+
+}
+
+// updateWheelScrolling scrolls the topmost scrollable Layout under the cursor with the mouse wheel.
+func (c *Context) updateWheelScrolling() {
+
+	if !c.players[0].updateSettings.UseMouse {
+		return
+	}
+
+	wx, wy := c.frameInput.Wheel()
+
+	if wx == 0 && wy == 0 {
+		return
+	}
+
+	for i := len(c.visibleLayouts) - 1; i >= 0; i-- {
+		if l := c.visibleLayouts[i]; !l.NoWheelScrolling && l.scrollable() && c.cursor.Inside(l.Rect) {
+			l.scrollVelocity = Vector2{}
+			l.scrollBy(Vector2{wx, wy}.Scale(wheelScrollDistance * ScrollWheelScrollSpeed))
+			l.userScrolled = true
+			break
+		}
+	}
+
+}
+
+// updateScrolling handles wheel and drag scrolling for the Context's visible Layouts.
+func (c *Context) updateScrolling() {
+
+	delta := c.deltaScale()
+
+	c.updateMouseDrag()
+	c.updateDragScrolling(delta)
+	c.updateWheelScrolling()
+
+	for _, layout := range c.visibleLayouts {
+		layout.updateScrollMomentum(delta)
+	}
+
+}
+
+// ClaimMouseDrag stops the mouse drag in progress (if any) from scrolling the Layout of the UI element being drawn.
+// Widgets that can be dragged with the mouse (like UISliders) should call this when the mouse starts dragging them.
+func (dc *DrawCall) ClaimMouseDrag() {
+	if ctx := dc.Context(); ctx.mouseDrag != nil && ctx.mouseDrag.owner == nil {
+		ctx.mouseDrag.owner = dc.Instance
+	}
+}
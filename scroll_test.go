@@ -0,0 +1,67 @@
+package gooey
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWheelScrollingStopsAutoScroll(t *testing.T) {
+
+	ctx := newTestContext()
+
+	provider := &testInputProvider{cursor: Vector2{50, 50}}
+	ctx.SetInputProvider(provider)
+
+	var list *Layout
+
+	frame := func(input UpdateSettings, draw func()) {
+		input.UseMouse = true
+		testFrame(ctx, func() {
+			list = ctx.NewLayout("list", 0, 0, 100, 100)
+			list.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+			for i := 0; i < 10; i++ {
+				NewUIWidget(testWidget{}).AddTo(list, fmt.Sprint("item", i))
+			}
+			if draw != nil {
+				draw()
+			}
+		}, input)
+	}
+
+	frame(UpdateSettings{}, func() { ctx.Highlight(list, "item0") })
+
+	if h := ctx.HighlightedUIElement(); h == nil || h.id != "item0" {
+		t.Fatalf("highlighted %v, want item0", h.ref())
+	}
+
+	// Scrolling down with the wheel moves the highlighted item offscreen, where it should stay.
+	provider.wheel = Vector2{0, -4}
+	frame(UpdateSettings{}, nil)
+	provider.wheel = Vector2{}
+
+	scrolled := list.Offset
+
+	if scrolled.Y >= 0 {
+		t.Fatalf("Offset is %v after scrolling the wheel, want it scrolled down", scrolled)
+	}
+
+	for i := 0; i < 60; i++ {
+		frame(UpdateSettings{}, nil)
+	}
+
+	if list.Offset != scrolled {
+		t.Errorf("Offset is %v a second after scrolling the wheel, want %v", list.Offset, scrolled)
+	}
+
+	// Navigating scrolls back to the highlighted item again.
+	frame(UpdateSettings{UpInput: true}, nil)
+
+	for i := 0; i < 60; i++ {
+		frame(UpdateSettings{}, nil)
+	}
+
+	if !list.Offset.IsZero() {
+		t.Errorf("Offset is %v a second after navigating, want it scrolled back to the top", list.Offset)
+	}
+
+}
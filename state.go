@@ -0,0 +1,83 @@
+package gooey
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+)
+
+// StateResetPolicyType specifies what should happen to a UI element instance's state when a different type of UI element
+// is drawn using the same ID, or when the stored state doesn't match the type requested through StateFor().
+type StateResetPolicyType int
+
+const (
+	StateResetPolicyReset     StateResetPolicyType = iota // Discard the state when the UI element type changes or the state type doesn't match. This is the default behavior.
+	StateResetPolicyKeepTyped                             // Keep the state when the UI element type changes; only discard it if the state type doesn't match.
+	StateResetPolicyWarn                                  // Like StateResetPolicyReset, but log.Println when state is discarded.
+	StateResetPolicyPanic                                 // panic() when the UI element type changes or the state type doesn't match.
+)
+
+// StateResetPolicy sets what should happen to a UI element instance's state when the type of UI element drawn
+// under an ID changes, or when the stored state's type doesn't match the type requested through StateFor().
+var StateResetPolicy StateResetPolicyType
+
+// StateFor returns the state of the given type for the UI element instance being drawn, lazily creating it if necessary.
+// This is how built-in UI elements store their per-instance state, and can be used from Widgets the same way:
+//
+//	state := gooey.StateFor[MyWidgetState](dc)
+//
+// If the instance has a state of a different type stored, it's handled according to StateResetPolicy.
+func StateFor[T any](dc *DrawCall) *T {
+	return StateForInit[T](dc, nil)
+}
+
+// StateForInit works like StateFor, but calls the given init function on the state when it's created.
+func StateForInit[T any](dc *DrawCall, init func(state *T)) *T {
+
+	inst := dc.Instance
+
+	if state, ok := inst.state.(*T); ok {
+		return state
+	}
+
+	if inst.state != nil {
+		inst.discardState(fmt.Sprintf("has a state of type %T, not %T", inst.state, (*T)(nil)))
+	}
+
+	state := new(T)
+	if init != nil {
+		init(state)
+	}
+	inst.state = state
+	return state
+
+}
+
+// discardState discards the instance's state according to StateResetPolicy.
+func (u *uiElementInstance) discardState(reason string) {
+	switch StateResetPolicy {
+	case StateResetPolicyPanic:
+		panic(fmt.Sprint("gooey: UI element ID [", u.id, "] ", reason, "."))
+	case StateResetPolicyWarn:
+		log.Println("gooey: UI element ID [", u.id, "]", reason, "; resetting its state.")
+	}
+	u.state = nil
+}
+
+// setDrawable sets the UI element drawn by the instance, discarding its state if the type of UI element changed.
+func (u *uiElementInstance) setDrawable(drawable UIElement) {
+	if u.state != nil && u.drawable != nil && StateResetPolicy != StateResetPolicyKeepTyped {
+		if prevType, newType := elementType(u.drawable), elementType(drawable); prevType != newType {
+			u.discardState(fmt.Sprintf("changed from a %v to a %v", prevType, newType))
+		}
+	}
+	u.drawable = drawable
+}
+
+// elementType returns the type of the given UI element; for UIWidgets, this is the type of the wrapped Widget.
+func elementType(element UIElement) reflect.Type {
+	if w, ok := element.(UIWidget); ok {
+		return reflect.TypeOf(w.Widget)
+	}
+	return reflect.TypeOf(element)
+}
@@ -0,0 +1,108 @@
+package gooey
+
+import "testing"
+
+// drawFunc is a Widget that draws by calling itself.
+type drawFunc func(dc *DrawCall)
+
+func (f drawFunc) Highlightable() bool {
+	return true
+}
+
+func (f drawFunc) Draw(dc *DrawCall) {
+	f(dc)
+}
+
+// wideStepper is a different type of Widget than stepper, with the same type of state.
+type wideStepper struct {
+	stepper
+}
+
+func TestStateResetPolicy(t *testing.T) {
+
+	defer func(policy StateResetPolicyType) { StateResetPolicy = policy }(StateResetPolicy)
+
+	// A stepper is drawn and its value set; then a wideStepper is drawn with the same ID.
+	tests := []struct {
+		name      string
+		policy    StateResetPolicyType
+		want      int
+		wantPanic bool
+	}{
+		{"reset", StateResetPolicyReset, 0, false},
+		{"keep typed", StateResetPolicyKeepTyped, 5, false},
+		{"warn", StateResetPolicyWarn, 0, false},
+		{"panic", StateResetPolicyPanic, 0, true},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			StateResetPolicy = test.policy
+
+			ctx := newTestContext()
+
+			testFrame(ctx, func() {
+				state := NewUIWidget(stepper{}).AddTo(ctx.NewLayout("menu", 0, 0, 100, 100), "volume").(*stepperState)
+				state.value = 5
+			})
+
+			var state *stepperState
+			panicked := false
+
+			func() {
+				defer func() { panicked = recover() != nil }()
+				testFrame(ctx, func() {
+					state = NewUIWidget(wideStepper{}).AddTo(ctx.NewLayout("menu", 0, 0, 100, 100), "volume").(*stepperState)
+				})
+			}()
+
+			if panicked != test.wantPanic {
+				t.Fatalf("panicked = %v, want %v", panicked, test.wantPanic)
+			}
+
+			if !test.wantPanic && state.value != test.want {
+				t.Errorf("state's value is %d after changing the Widget type, want %d", state.value, test.want)
+			}
+
+		})
+
+	}
+
+}
+
+func TestStateForMismatchedType(t *testing.T) {
+
+	defer func(policy StateResetPolicyType) { StateResetPolicy = policy }(StateResetPolicy)
+	StateResetPolicy = StateResetPolicyReset
+
+	ctx := newTestContext()
+
+	// The same Widget requests its state as a different type in each frame.
+	draws := []func(dc *DrawCall){
+		func(dc *DrawCall) { StateFor[stepperState](dc).value = 5 },
+		func(dc *DrawCall) {
+			if state := StateFor[stepperState](dc); state.value != 5 {
+				t.Errorf("state's value is %d, want the stored value 5", state.value)
+			}
+		},
+		func(dc *DrawCall) {
+			if state := StateFor[ButtonState](dc); dc.Instance.state != state {
+				t.Error("state of a different type wasn't stored")
+			}
+		},
+		func(dc *DrawCall) {
+			if state := StateFor[stepperState](dc); state.value != 0 {
+				t.Errorf("state's value is %d after its type changed, want 0", state.value)
+			}
+		},
+	}
+
+	for _, draw := range draws {
+		testFrame(ctx, func() {
+			NewUIWidget(drawFunc(draw)).AddTo(ctx.NewLayout("menu", 0, 0, 100, 100), "volume")
+		})
+	}
+
+}
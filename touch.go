@@ -0,0 +1,140 @@
+package gooey
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TouchDragDistance is how far (in screen buffer pixels) a touch needs to move from where it started to count as a
+// drag rather than a tap. Dragging a touch that started on a UIButton scrolls the Layout instead of pressing the button.
+var TouchDragDistance = float32(8)
+
+// Touch is a single finger touching the screen, as tracked by a Context.
+type Touch struct {
+	ID       ebiten.TouchID
+	Start    Vector2 // Where the touch started, in screen buffer space.
+	Position Vector2 // The touch's current position, in screen buffer space.
+	Previous Vector2 // The touch's position on the previous frame.
+	Released bool    // If the touch was released this frame; Position is its last position.
+	Dragging bool    // If the touch has moved further than TouchDragDistance from where it started.
+}
+
+type touchPoint struct {
+	Touch
+	started       bool               // If the touch started this frame
+	owner         *uiElementInstance // The UI element that claimed the touch, if any
+	releaseOnDrag bool               // If the owner lets go of the touch once it's dragged
+	scrolling     *Layout            // The Layout the touch is scrolling, if any
+}
+
+// updateTouches updates the Context's touches from its InputProvider, tracking each touch independently by its ID.
+func (c *Context) updateTouches() {
+
+	c.touches = slices.DeleteFunc(c.touches, func(t *touchPoint) bool { return t.Released })
+
+	c.touchIDs = c.frameInput.AppendTouchIDs(c.touchIDs[:0])
+	c.touchStarted = false
+
+	for _, t := range c.touches {
+		t.started = false
+		t.Previous = t.Position
+		t.Released = !slices.Contains(c.touchIDs, t.ID)
+	}
+
+	for _, id := range c.touchIDs {
+
+		pos := c.ScreenToBuffer(c.frameInput.TouchPosition(id))
+
+		if i := slices.IndexFunc(c.touches, func(t *touchPoint) bool { return t.ID == id }); i >= 0 {
+			c.touches[i].Position = pos
+			continue
+		}
+
+		c.touches = append(c.touches, &touchPoint{
+			Touch: Touch{
+				ID:       id,
+				Start:    pos,
+				Position: pos,
+				Previous: pos,
+			},
+			started: true,
+		})
+
+		c.touchStarted = true
+
+	}
+
+	for _, t := range c.touches {
+
+		if !t.Dragging && t.Position.DistanceTo(t.Start) > TouchDragDistance {
+			t.Dragging = true
+		}
+
+		if t.Dragging && t.releaseOnDrag {
+			t.owner = nil
+		}
+
+	}
+
+}
+
+// claimTouch returns the touch claimed by the UI element being drawn. If it hasn't claimed one, it claims a touch that
+// started inside its Rect this frame, if there is one. If releaseOnDrag is true, the UI element lets go of the touch
+// once it's dragged, so that it scrolls the Layout instead.
+func (dc *DrawCall) claimTouch(releaseOnDrag bool) *touchPoint {
+
+	ctx := dc.Context()
+
+	if !ctx.touchEnabled {
+		return nil
+	}
+
+	for _, t := range ctx.touches {
+		if t.owner == dc.Instance {
+			return t
+		}
+	}
+
+	for _, t := range ctx.touches {
+		if t.started && t.owner == nil && t.Start.Inside(dc.Rect) && t.Start.Inside(dc.Instance.layout.Rect) {
+			t.owner = dc.Instance
+			t.releaseOnDrag = releaseOnDrag
+			return t
+		}
+	}
+
+	return nil
+
+}
+
+// ClaimTouch returns the touch claimed by the UI element being drawn, claiming a touch that started inside the
+// DrawCall's Rect this frame if it hasn't claimed one yet. A claimed touch is tracked independently of any others
+// until it's released (i.e. Touch.Released is true), so several UI elements can each be dragged by a finger at once.
+// If releaseOnDrag is true, the UI element lets go of the touch once it's dragged (e.g. for a button inside a
+// scrolling Layout), after which ClaimTouch() returns false. Returns false if no touch is claimed.
+func (dc *DrawCall) ClaimTouch(releaseOnDrag bool) (Touch, bool) {
+	if t := dc.claimTouch(releaseOnDrag); t != nil {
+		return t.Touch, true
+	}
+	return Touch{}, false
+}
+
+// UsingTouch returns if touch input is currently being used to interact with the UI (as opposed to the mouse or directional input).
+func (dc *DrawCall) UsingTouch() bool {
+	return dc.Context().usingTouch
+}
+
+// Touches returns the touches currently tracked by the default Context. See Context.Touches().
+func Touches() []Touch {
+	return defaultContext.Touches()
+}
+
+// Touches returns the touches currently tracked by the Context, including those released this frame.
+func (c *Context) Touches() []Touch {
+	touches := make([]Touch, 0, len(c.touches))
+	for _, t := range c.touches {
+		touches = append(touches, t.Touch)
+	}
+	return touches
+}
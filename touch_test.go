@@ -0,0 +1,113 @@
+package gooey
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestTouchTapPressesButton(t *testing.T) {
+
+	ctx := newTestContext()
+
+	provider := &testInputProvider{touches: map[ebiten.TouchID]Vector2{}}
+	ctx.SetInputProvider(provider)
+
+	pressed := map[string]int{}
+
+	frame := func() {
+		testFrame(ctx, func() {
+
+			menu := ctx.NewLayout("menu", 0, 0, 40, 80)
+			menu.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+
+			for _, id := range []string{"a", "b"} {
+				if NewUIButton().AddTo(menu, id) {
+					pressed[id]++
+				}
+			}
+
+		}, UpdateSettings{UseTouch: true})
+	}
+
+	frame()
+
+	// A tap on the second button presses it once it's released.
+	provider.touches[1] = Vector2{20, 60}
+	frame()
+	delete(provider.touches, 1)
+	frame()
+	frame()
+
+	if pressed["a"] != 0 || pressed["b"] != 1 {
+		t.Errorf("pressed a %d times and b %d times after a tap, want 0 and 1", pressed["a"], pressed["b"])
+	}
+
+	// A touch that's dragged off of the button before it's released doesn't press it.
+	provider.touches[2] = Vector2{20, 20}
+	frame()
+	provider.touches[2] = Vector2{20, 60}
+	frame()
+	delete(provider.touches, 2)
+	frame()
+	frame()
+
+	if pressed["a"] != 0 || pressed["b"] != 1 {
+		t.Errorf("pressed a %d times and b %d times after a drag, want 0 and 1", pressed["a"], pressed["b"])
+	}
+
+}
+
+func TestTouchesDragIndependently(t *testing.T) {
+
+	ctx := newTestContext()
+
+	provider := &testInputProvider{touches: map[ebiten.TouchID]Vector2{}}
+	ctx.SetInputProvider(provider)
+
+	var treble, bass float32
+
+	frame := func() {
+		testFrame(ctx, func() {
+
+			mixer := ctx.NewLayout("mixer", 0, 0, 100, 40)
+			mixer.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 20}})
+
+			treble = NewUISlider().AddTo(mixer, "treble")
+			bass = NewUISlider().AddTo(mixer, "bass")
+
+		}, UpdateSettings{UseTouch: true})
+	}
+
+	frame()
+
+	// One finger drags each slider, moving at the same time in opposite directions.
+	provider.touches[1] = Vector2{10, 10}
+	provider.touches[2] = Vector2{90, 30}
+	frame()
+
+	for step := float32(1); step <= 4; step++ {
+		provider.touches[1] = Vector2{10 + step*15, 10}
+		provider.touches[2] = Vector2{90 - step*15, 30}
+		frame()
+	}
+
+	if len(ctx.Touches()) != 2 {
+		t.Errorf("tracking %d touches, want 2", len(ctx.Touches()))
+	}
+
+	if treble != 0.7 || bass != 0.3 {
+		t.Errorf("sliders at %v and %v, want 0.7 and 0.3", treble, bass)
+	}
+
+	// Releasing one finger leaves the other one dragging its slider.
+	delete(provider.touches, 2)
+	provider.touches[1] = Vector2{50, 10}
+	frame()
+	frame()
+
+	if treble != 0.5 || bass != 0.3 {
+		t.Errorf("sliders at %v and %v after releasing one touch, want 0.5 and 0.3", treble, bass)
+	}
+
+}
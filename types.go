@@ -15,6 +15,7 @@ type DrawCall struct {
 	InfluenceScrolling bool // If the elements being drawn should influence scrolling or not.
 
 	isHighlighted bool
+	player        int // The player whose input the element responds to; see DrawCall.Player()
 	rectSet       bool
 }
 
@@ -40,20 +41,26 @@ func (l *Layout) newDefaultDrawcall() *DrawCall {
 }
 
 // UIElement is an interface of properties UI elements must have.
+// To create your own UI elements, implement the Widget interface and wrap it in a UIWidget.
 type UIElement interface {
 	highlightable() bool     // Informs as to whether the UI element is highlightable (buttons) or not
 	draw(drawCall *DrawCall) // Draws using the information in the draw call struct, and then returns a state
 }
 
 type uiElementInstance struct {
-	id          string
-	currentRect Rect
-	prevRect    Rect
-	layout      *Layout
-	drawable    UIElement
-	state       any
-	wasDrawn    bool
-	data        any
+	id           string
+	hash         uint64
+	scope        uint64 // The hash of the ID scope the instance was added in; see Layout.PushID()
+	currentRect  Rect
+	prevRect     Rect
+	layout       *Layout
+	parent       *uiElementInstance // The instance that drew this one as a child, if any
+	elementIndex int
+	drawable     UIElement
+	state        any
+	wasDrawn     bool
+	drawnFrame   uint64
+	data         any
 }
 
 func (u *uiElementInstance) Clone() *uiElementInstance {
@@ -75,6 +82,12 @@ func (u *uiElementInstance) State() any {
 	return u.state
 }
 
+// SetState sets the persistent state of the UI Element. This is generally used by Widgets to store their
+// per-instance state between frames.
+func (u *uiElementInstance) SetState(state any) {
+	u.state = state
+}
+
 // Returns the Layout drawing the UI element.
 func (u *uiElementInstance) Layout() *Layout {
 	return u.layout
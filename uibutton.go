@@ -1,11 +1,5 @@
 package gooey
 
-import (
-	"strconv"
-
-	"github.com/hajimehoshi/ebiten/v2"
-)
-
 // UIButton represents a pressable / clickable UI element. You can add graphics to it by specifying its Graphics property.
 type UIButton struct {
 	BaseColor             Color       // The base color for the button.
@@ -107,10 +101,9 @@ func (b UIButton) highlightable() bool {
 
 func (b UIButton) draw(dc *DrawCall) {
 
-	if dc.Instance.state == nil {
-		dc.Instance.state = &ButtonState{}
-	}
-	state := dc.Instance.state.(*ButtonState)
+	ctx := dc.Instance.layout.context
+
+	state := StateFor[ButtonState](dc)
 
 	state.toggleable = b.Toggleable
 
@@ -118,15 +111,22 @@ func (b UIButton) draw(dc *DrawCall) {
 		b.ArrangerModifier(dc)
 	}
 
-	mouseX, mouseY := ebiten.CursorPosition()
+	hovering := ctx.cursor.Inside(dc.Rect)
 
-	hovering := Vector2{float32(mouseX), float32(mouseY)}.Inside(dc.Rect)
+	isHighlighted := ctx.usingMouse && hovering || dc.isHighlighted
 
-	isHighlighted := usingMouse && hovering || dc.isHighlighted
+	// Buttons let go of touches that are dragged, so that they can scroll the Layout instead
+	var touch *touchPoint
+	if !b.Disabled {
+		touch = dc.claimTouch(true)
+	}
 
-	if dc.isHighlighted || (usingMouse && hovering) {
+	if touch != nil && touch.Released && !touch.Position.Inside(dc.Rect) {
+		// Released outside of the button; cancel the press
+		state.pressedState = 0
+	} else if dc.isHighlighted || (ctx.usingMouse && hovering) || touch != nil {
 
-		if updateSettings.AcceptInput || (updateSettings.UseMouse && hovering && updateSettings.LeftMouseClick) {
+		if ctx.updateSettings.AcceptInput || (ctx.updateSettings.UseMouse && hovering && ctx.updateSettings.LeftMouseClick) || (touch != nil && !touch.Released) {
 			// Initial click
 			if !b.Disabled && state.pressedState == 0 {
 				state.pressedState = 1
@@ -144,8 +144,16 @@ func (b UIButton) draw(dc *DrawCall) {
 		state.pressedState = 0
 	}
 
-	if b.Toggleable && state.pressedState == 2 {
-		state.toggled = !state.toggled
+	// Dragging the mouse to scroll the Layout cancels the press
+	if ctx.mouseDragScrolling() {
+		state.pressedState = 0
+	}
+
+	if state.pressedState == 2 {
+		if b.Toggleable {
+			state.toggled = !state.toggled
+		}
+		ctx.pressed(dc.Instance)
 	}
 
 	buttonColor := b.BaseColor
@@ -165,15 +173,14 @@ func (b UIButton) draw(dc *DrawCall) {
 			if state.pressedState == 1 {
 				buttonColor = b.PressedColor
 			} else if isHighlighted {
-				buttonColor = b.HighlightColor
+				buttonColor = dc.HighlightColor(b.HighlightColor)
 			}
 		}
 	}
 
 	dc.Color = dc.Color.MultiplyRGBA(buttonColor.ToFloat32s())
 	if b.Graphics != nil {
-		dc.Instance.layout.add(dc.Instance.id+"__gfx", b.Graphics, dc.Clone())
-		dc.Instance.layout.Advance(-1)
+		dc.Instance.layout.addChild(dc.Instance, "__gfx", -1, b.Graphics, dc.Clone())
 	}
 
 	if b.Pointer != nil {
@@ -318,12 +325,7 @@ func (b *ButtonGroupState) updateButtonStates() {
 
 func (b UIButtonGroup) draw(dc *DrawCall) {
 
-	if dc.Instance.state == nil {
-		state := &ButtonGroupState{}
-		dc.Instance.state = state
-		// state.choice = 0
-	}
-	state := dc.Instance.state.(*ButtonGroupState)
+	state := StateFor[ButtonGroupState](dc)
 
 	if len(state.selected) < len(b.Options) {
 		state.selected = make([]bool, len(b.Options))
@@ -343,7 +345,8 @@ func (b UIButtonGroup) draw(dc *DrawCall) {
 	for index, option := range b.Options {
 		opt := b.BaseButton.WithText(option).WithToggleable(true)
 		newDC := dc.Instance.layout.newDefaultDrawcall()
-		dc.Instance.layout.add(dc.Instance.id+"__"+strconv.Itoa(index), opt, newDC)
+		dc.Instance.layout.addChild(dc.Instance, "__", index, opt, newDC)
+		dc.Instance.layout.Advance(1)
 		state.drawnButtons = append(state.drawnButtons, newDC.Instance.state.(*ButtonState))
 	}
 
@@ -1,9 +1,5 @@
 package gooey
 
-import (
-	"strconv"
-)
-
 // UICollection is a set of options for rendering UI elements together.
 // While any element can be placed within a Collection, if you want to
 // retrieve the state of the element or if the element needs to be
@@ -56,8 +52,7 @@ func (c UICollection) draw(dc *DrawCall) {
 	}
 
 	for index, element := range c.Elements {
-		dc.Instance.layout.add(dc.Instance.id+"__"+strconv.Itoa(index), element, dc.Clone())
-		dc.Instance.layout.Advance(-1)
+		dc.Instance.layout.addChild(dc.Instance, "__", index, element, dc.Clone())
 	}
 
 }
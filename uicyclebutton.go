@@ -1,9 +1,5 @@
 package gooey
 
-import (
-	"github.com/hajimehoshi/ebiten/v2"
-)
-
 // UICycleButton draws a button that cycles left to right or top to bottom between a set of choices.
 type UICycleButton struct {
 	Options []string // The choices to cycle between.
@@ -24,6 +20,8 @@ type UICycleButton struct {
 
 	Disabled bool // Whether the button is disabled or not; when disabled, it cannot be pressed or highlighted.
 
+	EditMode EditModeType // How the button responds to directional input when highlighted; see EditModeAccept.
+
 	// Whether the button is clickable to the right and left, or top and bottom,
 	// and if you press right and left, or up and down, to cycle through its options.
 	Vertical bool
@@ -116,6 +114,11 @@ func (b UICycleButton) WithVertical(vertical bool) UICycleButton {
 	return b
 }
 
+func (b UICycleButton) WithEditMode(mode EditModeType) UICycleButton {
+	b.EditMode = mode
+	return b
+}
+
 func (b UICycleButton) WithPointer(pointer *int) UICycleButton {
 	b.Pointer = pointer
 	return b
@@ -142,25 +145,21 @@ func (b UICycleButton) WithPointer(pointer *int) UICycleButton {
 
 func (b UICycleButton) draw(dc *DrawCall) {
 
+	ctx := dc.Instance.layout.context
+
 	if b.ArrangerModifier != nil {
 		b.ArrangerModifier(dc)
 	}
 
-	if dc.Instance.state == nil {
-		s := &CycleButtonState{}
-		dc.Instance.state = s
+	state := StateForInit(dc, func(state *CycleButtonState) {
 		if b.Pointer != nil {
-			s.selected = *b.Pointer
+			state.selected = *b.Pointer
 		}
-	}
-
-	state := dc.Instance.state.(*CycleButtonState)
+	})
 
 	color := b.BaseColor
 
-	mouseX, mouseY := ebiten.CursorPosition()
-
-	hovering := Vector2{float32(mouseX), float32(mouseY)}.Inside(dc.Rect)
+	hovering := ctx.cursor.Inside(dc.Rect)
 
 	prevZone := dc.Rect
 	prevZone.W = b.ClickZoneSize
@@ -178,37 +177,64 @@ func (b UICycleButton) draw(dc *DrawCall) {
 		prevZone = nextZone.SetBottom(dc.Rect.Bottom())
 	}
 
-	if dc.isHighlighted || (usingMouse && hovering) {
-		color = b.HighlightColor
+	if dc.isHighlighted || (ctx.usingMouse && hovering) {
+		color = dc.HighlightColor(b.HighlightColor)
+	}
+
+	adjust := false
+
+	if !b.Disabled {
+
+		var edit EditEvent
+		adjust, edit = dc.UpdateEditMode(b.EditMode)
+
+		switch edit {
+		case EditEventBegin:
+			state.editStart = state.selected
+		case EditEventCancel:
+			state.selected = state.editStart
+		}
+
 	}
 
 	if b.Disabled {
 		color = b.DisabledColor
-	} else if dc.isHighlighted {
+	} else if adjust {
 
 		if !b.Vertical {
 
-			if queuedInput == queuedInputLeft {
+			if ctx.queuedInput == queuedInputLeft {
 				state.selected--
-				queuedInput = queuedInputNone
-			} else if queuedInput == queuedInputRight {
+				ctx.queuedInput = queuedInputNone
+			} else if ctx.queuedInput == queuedInputRight {
 				state.selected++
-				queuedInput = queuedInputNone
+				ctx.queuedInput = queuedInputNone
 			}
 
 		} else {
 
-			if queuedInput == queuedInputUp {
+			if ctx.queuedInput == queuedInputUp {
 				state.selected--
-				queuedInput = queuedInputNone
-			} else if queuedInput == queuedInputDown {
+				ctx.queuedInput = queuedInputNone
+			} else if ctx.queuedInput == queuedInputDown {
 				state.selected++
-				queuedInput = queuedInputNone
+				ctx.queuedInput = queuedInputNone
 			}
 		}
 
 	}
 
+	// Tapping the previous or next zones cycles through the options
+	if !b.Disabled {
+		if touch := dc.claimTouch(true); touch != nil && touch.Released {
+			if prevZone.ContainsPoint(touch.Position) {
+				state.selected--
+			} else if nextZone.ContainsPoint(touch.Position) {
+				state.selected++
+			}
+		}
+	}
+
 	if state.selected < 0 {
 		state.selected = len(b.Options) - 1
 	} else if state.selected >= len(b.Options) {
@@ -227,8 +253,7 @@ func (b UICycleButton) draw(dc *DrawCall) {
 	dc.Color = dc.Color.MultiplyRGBA(color.ToFloat32s())
 	if b.GraphicsBody != nil {
 		setTextForAllLabelsInGraphic(b.GraphicsBody, txt)
-		dc.Instance.layout.add(dc.Instance.id+"__gfx_body", b.GraphicsBody, dc.Clone())
-		dc.Instance.layout.Advance(-1)
+		dc.Instance.layout.addChild(dc.Instance, "__gfx_body", -1, b.GraphicsBody, dc.Clone())
 	}
 
 	if b.GraphicsButtonPrevious != nil {
@@ -253,17 +278,17 @@ func (b UICycleButton) draw(dc *DrawCall) {
 
 		if b.Disabled {
 			zoneColor = b.GraphicsButtonDisabledColor
-		} else if usingMouse && hovering {
+		} else if ctx.usingMouse && hovering {
 
 			// Left Zone
-			if prevZone.ContainsPoint(Vector2{X: float32(mouseX), Y: float32(mouseY)}) {
+			if prevZone.ContainsPoint(ctx.cursor) {
 
 				zoneColor = b.GraphicsButtonHighlightColor
 
-				if repeatingMouseClick {
+				if ctx.repeatingMouseClick {
 					state.selected--
 				}
-				if updateSettings.LeftMouseClick {
+				if ctx.updateSettings.LeftMouseClick {
 					zoneColor = b.GraphicsButtonPressedColor
 				}
 			}
@@ -276,8 +301,7 @@ func (b UICycleButton) draw(dc *DrawCall) {
 
 		newDrawcall.Color = zoneColor
 
-		dc.Instance.layout.add(dc.Instance.id+"__gfx_button_left", b.GraphicsButtonPrevious, newDrawcall)
-		dc.Instance.layout.Advance(-1)
+		dc.Instance.layout.addChild(dc.Instance, "__gfx_button_left", -1, b.GraphicsButtonPrevious, newDrawcall)
 	}
 
 	if b.GraphicsButtonNext != nil {
@@ -308,17 +332,17 @@ func (b UICycleButton) draw(dc *DrawCall) {
 
 		if b.Disabled {
 			zoneColor = b.GraphicsButtonDisabledColor
-		} else if usingMouse && hovering {
+		} else if ctx.usingMouse && hovering {
 
 			// Left Zone
-			if nextZone.ContainsPoint(Vector2{X: float32(mouseX), Y: float32(mouseY)}) {
+			if nextZone.ContainsPoint(ctx.cursor) {
 
 				zoneColor = b.GraphicsButtonHighlightColor
 
-				if repeatingMouseClick {
+				if ctx.repeatingMouseClick {
 					state.selected++
 				}
-				if updateSettings.LeftMouseClick {
+				if ctx.updateSettings.LeftMouseClick {
 					zoneColor = b.GraphicsButtonPressedColor
 				}
 			}
@@ -331,8 +355,7 @@ func (b UICycleButton) draw(dc *DrawCall) {
 
 		newDrawcall.Color = zoneColor
 
-		dc.Instance.layout.add(dc.Instance.id+"__gfx_button_right", b.GraphicsButtonNext, newDrawcall)
-		dc.Instance.layout.Advance(-1)
+		dc.Instance.layout.addChild(dc.Instance, "__gfx_button_right", -1, b.GraphicsButtonNext, newDrawcall)
 	}
 
 	// state.selected = 0
@@ -353,5 +376,6 @@ func (b UICycleButton) AddTo(layout *Layout, id string) int {
 }
 
 type CycleButtonState struct {
-	selected int
+	selected  int
+	editStart int
 }
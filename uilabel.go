@@ -124,11 +124,7 @@ type LabelState struct {
 
 func (l UILabel) draw(dc *DrawCall) {
 
-	if dc.Instance.state == nil {
-		dc.Instance.state = &LabelState{}
-	}
-
-	state := dc.Instance.state.(*LabelState)
+	state := StateFor[LabelState](dc)
 
 	state.targetText = []rune(l.Text)
 
@@ -0,0 +1,313 @@
+package gooey
+
+// UIScrollbar is a UI element that displays and controls the scroll position of another Layout (its Target).
+// The thumb is sized according to how much of the Target's contents fit within its Rect, and can be dragged with the
+// mouse or by touch; clicking or tapping the track on either side of the thumb scrolls the Target by a page.
+// Like UISliders, UIScrollbars are horizontal when they're wider than they are tall, and vertical otherwise.
+// UIScrollbars can't be highlighted, as a Layout already automatically scrolls to show its highlighted UI element.
+type UIScrollbar struct {
+	Target *Layout // The Layout to scroll. This should be a different Layout from the one the scrollbar is added to.
+
+	Background            UIElement   // A UI element to use for drawing the track of the scrollbar.
+	ThumbGraphics         UIElement   // A UI element to use for drawing the thumb. It's stretched to the thumb's size, so a UIImage using StretchModeThreepatch works well.
+	ArrowPreviousGraphics UIElement   // A UI element to draw at the top (or left) end of the scrollbar while there's more content to scroll to in that direction. Space is reserved for it if set.
+	ArrowNextGraphics     UIElement   // A UI element to draw at the bottom (or right) end of the scrollbar while there's more content to scroll to in that direction. Space is reserved for it if set.
+	ArrangerModifier      ArrangeFunc // A customizeable modifier that alters the location where the UI element is going to render.
+
+	BaseColor      Color   // The color to use for the scrollbar by default.
+	HighlightColor Color   // The color to use for the scrollbar when the mouse hovers over it or its thumb is being dragged.
+	DisabledColor  Color   // The color to use for the scrollbar when it is disabled.
+	MinThumbSize   float32 // The minimum length of the thumb, in pixels, so that it stays easy to grab for long contents. If 0, 16 is used.
+
+	Disabled bool // If the scrollbar is disabled.
+}
+
+// Creates a new UIScrollbar that scrolls the given Layout, with sensible default values.
+func NewUIScrollbar(target *Layout) UIScrollbar {
+	return UIScrollbar{
+		Target:       target,
+		MinThumbSize: 16,
+	}
+}
+
+func (s UIScrollbar) WithTarget(target *Layout) UIScrollbar {
+	s.Target = target
+	return s
+}
+
+func (s UIScrollbar) WithBackground(bg UIElement) UIScrollbar {
+	s.Background = bg
+	return s
+}
+
+func (s UIScrollbar) WithThumbGraphics(thumb UIElement) UIScrollbar {
+	s.ThumbGraphics = thumb
+	return s
+}
+
+func (s UIScrollbar) WithArrowGraphics(previous, next UIElement) UIScrollbar {
+	s.ArrowPreviousGraphics = previous
+	s.ArrowNextGraphics = next
+	return s
+}
+
+func (s UIScrollbar) WithArrangerModifier(modifier ArrangeFunc) UIScrollbar {
+	s.ArrangerModifier = modifier
+	return s
+}
+
+func (s UIScrollbar) WithBaseColor(color Color) UIScrollbar {
+	s.BaseColor = color
+	return s
+}
+
+func (s UIScrollbar) WithHighlightColor(color Color) UIScrollbar {
+	s.HighlightColor = color
+	return s
+}
+
+func (s UIScrollbar) WithDisabledColor(color Color) UIScrollbar {
+	s.DisabledColor = color
+	return s
+}
+
+func (s UIScrollbar) WithMinThumbSize(size float32) UIScrollbar {
+	s.MinThumbSize = size
+	return s
+}
+
+func (s UIScrollbar) WithDisabled(disabled bool) UIScrollbar {
+	s.Disabled = disabled
+	return s
+}
+
+func (s UIScrollbar) highlightable() bool {
+	return false
+}
+
+type ScrollbarState struct {
+	percentage float32
+	dragging   bool
+	grab       float32 // How far along the thumb it was grabbed
+	thumbRect  Rect
+}
+
+// Percentage returns how far the Target is scrolled, from 0 (at the start of its contents) to 1 (at the end).
+func (s *ScrollbarState) Percentage() float32 {
+	return s.percentage
+}
+
+// ThumbRect returns the rectangle the scrollbar's thumb was last drawn in.
+func (s *ScrollbarState) ThumbRect() Rect {
+	return s.thumbRect
+}
+
+func (s UIScrollbar) draw(dc *DrawCall) {
+
+	ctx := dc.Instance.layout.context
+
+	state := StateFor[ScrollbarState](dc)
+
+	if s.ArrangerModifier != nil {
+		s.ArrangerModifier(dc)
+	}
+
+	horizontal := dc.Rect.H <= dc.Rect.W
+
+	// Work along the scrollbar's axis, so the same logic handles both horizontal and vertical scrollbars
+	along := func(v Vector2) float32 {
+		if horizontal {
+			return v.X
+		}
+		return v.Y
+	}
+
+	minThumbSize := s.MinThumbSize
+	if minThumbSize <= 0 {
+		minThumbSize = 16
+	}
+
+	arrowSize := min(dc.Rect.W, dc.Rect.H)
+
+	prevArrow := dc.Rect
+	nextArrow := dc.Rect
+	track := dc.Rect
+
+	if horizontal {
+		prevArrow.W = arrowSize
+		nextArrow.W = arrowSize
+		nextArrow = nextArrow.SetRight(dc.Rect.Right())
+		if s.ArrowPreviousGraphics != nil {
+			track = track.ScaleLeftTo(prevArrow.Right())
+		}
+		if s.ArrowNextGraphics != nil {
+			track = track.ScaleRightTo(nextArrow.X)
+		}
+	} else {
+		prevArrow.H = arrowSize
+		nextArrow.H = arrowSize
+		nextArrow = nextArrow.SetBottom(dc.Rect.Bottom())
+		if s.ArrowPreviousGraphics != nil {
+			track = track.ScaleUpTo(prevArrow.Bottom())
+		}
+		if s.ArrowNextGraphics != nil {
+			track = track.ScaleDownTo(nextArrow.Y)
+		}
+	}
+
+	trackStart := along(Vector2{track.X, track.Y})
+	trackLength := max(along(Vector2{track.W, track.H}), 0)
+
+	view := float32(0)
+	scrollRange := float32(0)
+
+	if s.Target != nil {
+		view = along(Vector2{s.Target.Rect.W, s.Target.Rect.H})
+		scrollRange = along(Vector2{s.Target.contentRect.W, s.Target.contentRect.H}) - view
+	}
+
+	// thumb returns the thumb's start and length along the track for the Target's current Offset.
+	thumb := func() (start, length float32) {
+
+		if scrollRange <= 0 {
+			state.percentage = 0
+			return trackStart, trackLength
+		}
+
+		length = clamp(trackLength*view/(view+scrollRange), min(minThumbSize, trackLength), trackLength)
+		state.percentage = clamp(-along(s.Target.Offset)/scrollRange, 0, 1)
+		return trackStart + state.percentage*(trackLength-length), length
+
+	}
+
+	// scroll moves the Target's Offset along the scrollbar's axis, stopping any momentum it had from being dragged.
+	scroll := func(delta float32) {
+		s.Target.scrollVelocity = Vector2{}
+		if horizontal {
+			s.Target.scrollBy(Vector2{delta, 0})
+		} else {
+			s.Target.scrollBy(Vector2{0, delta})
+		}
+	}
+
+	thumbStart, thumbLength := thumb()
+
+	hovering := ctx.cursor.Inside(track)
+
+	if !s.Disabled && scrollRange > 0 {
+
+		pointer := ctx.cursor
+		pressed := false
+		held := false
+
+		if touch := dc.claimTouch(false); touch != nil {
+			pointer = touch.Position
+			pressed = touch.started && touch.Start.Inside(track)
+			held = !touch.Released
+		} else if ctx.usingMouse {
+			pressed = hovering && ctx.justClicked
+			held = ctx.updateSettings.LeftMouseClick
+		}
+
+		p := along(pointer)
+		onThumb := p >= thumbStart && p <= thumbStart+thumbLength
+
+		if pressed && onThumb {
+			state.dragging = true
+			state.grab = p - thumbStart
+			dc.ClaimMouseDrag()
+		} else if !state.dragging && !onThumb && (pressed || (ctx.usingMouse && hovering && ctx.repeatingMouseClick)) {
+			// Page towards the pointer; holding the mouse button down keeps paging
+			if p < thumbStart {
+				scroll(view)
+			} else {
+				scroll(-view)
+			}
+			dc.ClaimMouseDrag()
+		}
+
+		if !held {
+			state.dragging = false
+		}
+
+		if state.dragging && trackLength > thumbLength {
+			start := clamp(p-state.grab, trackStart, trackStart+trackLength-thumbLength)
+			target := -(start - trackStart) / (trackLength - thumbLength) * scrollRange
+			scroll(target - along(s.Target.Offset))
+		}
+
+		thumbStart, thumbLength = thumb()
+
+	} else {
+		state.dragging = false
+	}
+
+	baseColor := s.BaseColor
+	if baseColor.IsZero() {
+		baseColor = NewColor(0.8, 0.8, 0.8, 1)
+	}
+
+	if s.Disabled {
+
+		if s.DisabledColor.IsZero() {
+			baseColor = baseColor.SubRGBA(0.4, 0.4, 0.4, 0)
+		} else {
+			baseColor = s.DisabledColor
+		}
+
+	} else if state.dragging || (ctx.usingMouse && hovering) {
+
+		if s.HighlightColor.IsZero() {
+			baseColor = baseColor.AddRGBA(0.2, 0.2, 0.2, 1)
+		} else {
+			baseColor = s.HighlightColor
+		}
+
+	}
+
+	dc.Color = dc.Color.MultiplyRGBA(baseColor.ToFloat32s())
+
+	if s.Background != nil {
+		bgDC := dc.Clone()
+		bgDC.Rect = track
+		dc.Instance.layout.addChild(dc.Instance, "__bg", -1, s.Background, bgDC)
+	}
+
+	state.thumbRect = track
+	if horizontal {
+		state.thumbRect.X = thumbStart
+		state.thumbRect.W = thumbLength
+	} else {
+		state.thumbRect.Y = thumbStart
+		state.thumbRect.H = thumbLength
+	}
+
+	if s.ThumbGraphics != nil {
+		thumbDC := dc.Clone()
+		thumbDC.Rect = state.thumbRect
+		dc.Instance.layout.addChild(dc.Instance, "__thumb", -1, s.ThumbGraphics, thumbDC)
+	}
+
+	// The arrows only show while there's more content to scroll to in their direction
+	if s.ArrowPreviousGraphics != nil && scrollRange > 0 && state.percentage > 0 {
+		arrowDC := dc.Clone()
+		arrowDC.Rect = prevArrow
+		dc.Instance.layout.addChild(dc.Instance, "__arrow_prev", -1, s.ArrowPreviousGraphics, arrowDC)
+	}
+
+	if s.ArrowNextGraphics != nil && scrollRange > 0 && state.percentage < 1 {
+		arrowDC := dc.Clone()
+		arrowDC.Rect = nextArrow
+		dc.Instance.layout.addChild(dc.Instance, "__arrow_next", -1, s.ArrowNextGraphics, arrowDC)
+	}
+
+}
+
+// AddTo adds the UI element to the given Layout.
+// The id string should be unique and is used to identify and keep track of its location and internal state, if it saves any such state.
+// The function returns how far the Target is scrolled, from 0 (at the start of its contents) to 1 (at the end).
+func (s UIScrollbar) AddTo(layout *Layout, id string) float32 {
+	dc := layout.newDefaultDrawcall()
+	layout.add(id, s, dc)
+	return dc.Instance.state.(*ScrollbarState).Percentage()
+}
@@ -0,0 +1,66 @@
+package gooey
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestScrollbarThumb(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		scrollbar    UIScrollbar
+		offset       float32
+		wantLength   float32
+		wantProgress float32
+	}{
+		{"unset minimum thumb size", UIScrollbar{}, 0, 16, 0},
+		{"minimum thumb size", UIScrollbar{MinThumbSize: 32}, 0, 32, 0},
+		{"halfway", UIScrollbar{MinThumbSize: 32}, -1950, 32, 0.5},
+		{"end", UIScrollbar{MinThumbSize: 32}, -3900, 32, 1},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			ctx := newTestContext()
+
+			var state *ScrollbarState
+			progress := float32(0)
+
+			// The list's contents are 40 times taller than it is, so the thumb is shorter than the minimum size.
+			for frame := 0; frame < 2; frame++ {
+
+				testFrame(ctx, func() {
+
+					list := ctx.NewLayout("list", 0, 0, 100, 100)
+					list.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 40}})
+					list.Offset.Y = test.offset
+
+					for i := 0; i < 100; i++ {
+						NewUIWidget(testWidget{}).AddTo(list, fmt.Sprint("item", i))
+					}
+
+					bar := ctx.NewLayout("bar", 100, 0, 10, 100)
+					scrollbar := test.scrollbar.WithTarget(list)
+					progress = scrollbar.AddTo(bar, "scrollbar")
+					state = bar.UIElement("scrollbar").state.(*ScrollbarState)
+
+				})
+
+			}
+
+			if length := state.ThumbRect().H; length != test.wantLength {
+				t.Errorf("thumb is %v pixels long, want %v", length, test.wantLength)
+			}
+
+			if progress != test.wantProgress || state.Percentage() != test.wantProgress {
+				t.Errorf("scrolled %v (state %v), want %v", progress, state.Percentage(), test.wantProgress)
+			}
+
+		})
+
+	}
+
+}
@@ -3,8 +3,6 @@ package gooey
 import (
 	"math"
 	"strconv"
-
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type UISlider struct {
@@ -18,7 +16,8 @@ type UISlider struct {
 	SliderHeadLerpPercentage float32 // What percentage to lerp the slider between.
 	StepSize                 float32 // How coarse in percentages the slider is. Defaults to 0.1 (10%).
 
-	Disabled bool // If the slider is disabled.
+	Disabled bool         // If the slider is disabled.
+	EditMode EditModeType // How the slider responds to directional input when highlighted; see EditModeAccept.
 
 	Pointer *float32 // A pointer to a variable to set for the slider to represent.
 }
@@ -65,6 +64,11 @@ func (s UISlider) WithDisabled(disabled bool) UISlider {
 	return s
 }
 
+func (s UISlider) WithEditMode(mode EditModeType) UISlider {
+	s.EditMode = mode
+	return s
+}
+
 func (s UISlider) WithPointer(pointer *float32) UISlider {
 	s.Pointer = pointer
 	return s
@@ -88,6 +92,7 @@ type SliderState struct {
 	held               bool
 	sliderHeadPosition Vector2
 	disabled           bool
+	editStart          float32
 }
 
 // Returns the percentage of the slider as a string.
@@ -105,16 +110,14 @@ func (s *SliderState) SliderHeadPosition() Vector2 {
 
 func (s UISlider) draw(dc *DrawCall) {
 
-	if dc.Instance.state == nil {
-		state := &SliderState{}
+	ctx := dc.Instance.layout.context
+
+	state := StateForInit(dc, func(state *SliderState) {
 		if s.Pointer != nil {
 			state.Percentage = *s.Pointer
 			state.visualPercentage = state.Percentage
 		}
-		dc.Instance.state = state
-	}
-
-	state := dc.Instance.state.(*SliderState)
+	})
 	state.disabled = s.Disabled
 
 	if s.ArrangerModifier != nil {
@@ -131,12 +134,12 @@ func (s UISlider) draw(dc *DrawCall) {
 		baseColor = NewColor(0.8, 0.8, 0.8, 1)
 	}
 
-	mouseX, mouseY := ebiten.CursorPosition()
-
-	hovering := Vector2{float32(mouseX), float32(mouseY)}.Inside(dc.Rect)
+	hovering := ctx.cursor.Inside(dc.Rect)
 
 	horizontal := dc.Rect.H <= dc.Rect.W
 
+	pointer := ctx.cursor // The position of the cursor or touch dragging the slider head
+
 	if s.Disabled {
 
 		if s.DisabledColor.IsZero() {
@@ -147,7 +150,7 @@ func (s UISlider) draw(dc *DrawCall) {
 
 	} else {
 
-		if highlightedElement == dc.Instance || (usingMouse && ((hovering && !updateSettings.LeftMouseClick) || state.held)) {
+		if ctx.highlightedElement == dc.Instance || (ctx.usingMouse && ((hovering && !ctx.updateSettings.LeftMouseClick) || state.held)) || (ctx.usingTouch && state.held) {
 
 			if s.HighlightColor.IsZero() {
 				baseColor = baseColor.AddRGBA(0.2, 0.2, 0.2, 1)
@@ -155,36 +158,53 @@ func (s UISlider) draw(dc *DrawCall) {
 				baseColor = s.HighlightColor
 			}
 
+			baseColor = dc.HighlightColor(baseColor)
+
+		}
+
+		adjust, edit := dc.UpdateEditMode(s.EditMode)
+
+		switch edit {
+		case EditEventBegin:
+			state.editStart = state.Percentage
+		case EditEventCancel:
+			state.Percentage = state.editStart
 		}
 
-		if usingMouse {
+		if touch := dc.claimTouch(false); touch != nil {
+
+			state.held = !touch.Released
+			pointer = touch.Position
 
-			if hovering && justClicked {
+		} else if ctx.usingMouse {
+
+			if hovering && ctx.justClicked {
 				state.held = true
-			} else if !updateSettings.LeftMouseClick {
+				dc.ClaimMouseDrag()
+			} else if !ctx.updateSettings.LeftMouseClick {
 				state.held = false
 			}
 
-		} else if highlightedElement == dc.Instance {
+		} else if adjust {
 
 			if horizontal {
 
-				if queuedInput == queuedInputRight {
+				if ctx.queuedInput == queuedInputRight {
 					state.Percentage += stepSize
-					queuedInput = queuedInputNone
-				} else if queuedInput == queuedInputLeft {
+					ctx.queuedInput = queuedInputNone
+				} else if ctx.queuedInput == queuedInputLeft {
 					state.Percentage -= stepSize
-					queuedInput = queuedInputNone
+					ctx.queuedInput = queuedInputNone
 				}
 
 			} else {
 
-				if queuedInput == queuedInputUp {
+				if ctx.queuedInput == queuedInputUp {
 					state.Percentage -= stepSize
-					queuedInput = queuedInputNone
-				} else if queuedInput == queuedInputDown {
+					ctx.queuedInput = queuedInputNone
+				} else if ctx.queuedInput == queuedInputDown {
 					state.Percentage += stepSize
-					queuedInput = queuedInputNone
+					ctx.queuedInput = queuedInputNone
 				}
 
 			}
@@ -194,9 +214,8 @@ func (s UISlider) draw(dc *DrawCall) {
 	}
 
 	if state.held {
-		clickX, clickY := ebiten.CursorPosition()
-		percX := (float32(clickX) - dc.Rect.X) / dc.Rect.W
-		percY := (float32(clickY) - dc.Rect.Y) / dc.Rect.H
+		percX := (pointer.X - dc.Rect.X) / dc.Rect.W
+		percY := (pointer.Y - dc.Rect.Y) / dc.Rect.H
 
 		if horizontal {
 			state.Percentage = percX
@@ -217,8 +236,7 @@ func (s UISlider) draw(dc *DrawCall) {
 	dc.Color = dc.Color.MultiplyRGBA(baseColor.ToFloat32s())
 
 	if s.Background != nil {
-		dc.Instance.layout.add(dc.Instance.id+"__bg", s.Background, dc.Clone())
-		dc.Instance.layout.Advance(-1)
+		dc.Instance.layout.addChild(dc.Instance, "__bg", -1, s.Background, dc.Clone())
 	}
 
 	if s.SliderGraphics != nil {
@@ -228,7 +246,11 @@ func (s UISlider) draw(dc *DrawCall) {
 		if s.SliderHeadLerpPercentage <= 0 {
 			state.visualPercentage = state.Percentage
 		} else {
-			state.visualPercentage += (state.Percentage - state.visualPercentage) * s.SliderHeadLerpPercentage
+			lerp := s.SliderHeadLerpPercentage
+			if delta := ctx.deltaScale(); delta != 1 {
+				lerp = 1 - float32(math.Pow(float64(1-clamp(lerp, 0, 1)), float64(delta)))
+			}
+			state.visualPercentage += (state.Percentage - state.visualPercentage) * lerp
 		}
 
 		sliderRect := newDC.Rect
@@ -245,8 +267,7 @@ func (s UISlider) draw(dc *DrawCall) {
 
 		newDC.Rect = sliderRect
 
-		newDC.Instance.layout.add(newDC.Instance.id+"__sliderobj", s.SliderGraphics, newDC)
-		newDC.Instance.layout.Advance(-1)
+		newDC.Instance.layout.addChild(dc.Instance, "__sliderobj", -1, s.SliderGraphics, newDC)
 
 	}
 
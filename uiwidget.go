@@ -0,0 +1,141 @@
+package gooey
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Widget is the interface to implement to create your own UI elements outside of gooey.
+// Wrap a Widget in a UIWidget (using NewUIWidget()) to add it to Layouts or to use it as graphics for other UI elements.
+type Widget interface {
+	Highlightable() bool // Informs as to whether the widget can be highlighted.
+	Draw(dc *DrawCall)   // Draws the widget using the information in the draw call; see the DrawCall's methods for input and state.
+}
+
+// UIWidget is a UI element that draws a user-created Widget.
+type UIWidget struct {
+	Widget           Widget      // The Widget to draw.
+	ArrangerModifier ArrangeFunc // A customizeable modifier that alters the location where the UI element is going to render.
+}
+
+// NewUIWidget creates a new UIWidget that draws the given Widget.
+func NewUIWidget(widget Widget) UIWidget {
+	return UIWidget{
+		Widget: widget,
+	}
+}
+
+func (w UIWidget) WithWidget(widget Widget) UIWidget {
+	w.Widget = widget
+	return w
+}
+
+func (w UIWidget) WithArrangerModifier(modifier ArrangeFunc) UIWidget {
+	w.ArrangerModifier = modifier
+	return w
+}
+
+func (w UIWidget) highlightable() bool {
+	return w.Widget != nil && w.Widget.Highlightable()
+}
+
+func (w UIWidget) draw(dc *DrawCall) {
+
+	if w.ArrangerModifier != nil {
+		w.ArrangerModifier(dc)
+	}
+
+	if w.Widget != nil {
+		w.Widget.Draw(dc)
+	}
+
+}
+
+// AddTo adds the UI element to the given Layout.
+// The id string should be unique and is used to identify and keep track of its location and internal state, if it saves any such state.
+// The function returns the UI element instance's state, as set by the Widget.
+func (w UIWidget) AddTo(layout *Layout, id string) any {
+	dc := layout.newDefaultDrawcall()
+	layout.add(id, w, dc)
+	return dc.Instance.state
+}
+
+// NavigationInput indicates a highlighting control input (i.e. directional, accept, or cancel input) that was
+// pressed for the current frame.
+type NavigationInput int
+
+const (
+	NavigationInputNone   NavigationInput = queuedInputNone
+	NavigationInputRight  NavigationInput = queuedInputRight
+	NavigationInputLeft   NavigationInput = queuedInputLeft
+	NavigationInputUp     NavigationInput = queuedInputUp
+	NavigationInputDown   NavigationInput = queuedInputDown
+	NavigationInputPrev   NavigationInput = queuedInputPrev
+	NavigationInputNext   NavigationInput = queuedInputNext
+	NavigationInputAccept NavigationInput = queuedInputSelect
+	NavigationInputCancel NavigationInput = queuedInputCancel
+)
+
+// Context returns the Context the element is being drawn in.
+func (dc *DrawCall) Context() *Context {
+	return dc.Instance.layout.context
+}
+
+// Screen returns the image the element should draw to (the portion of the screen buffer covered by the Layout).
+func (dc *DrawCall) Screen() *ebiten.Image {
+	return dc.Instance.layout.subscreen()
+}
+
+// Cursor returns the cursor position in screen buffer space.
+func (dc *DrawCall) Cursor() Vector2 {
+	return dc.Context().cursor
+}
+
+// UsingMouse returns if the mouse is currently being used to interact with the UI (as opposed to directional input).
+func (dc *DrawCall) UsingMouse() bool {
+	return dc.Context().usingMouse
+}
+
+// Hovered returns if the mouse is being used and the cursor is over the DrawCall's Rect.
+func (dc *DrawCall) Hovered() bool {
+	ctx := dc.Context()
+	return ctx.usingMouse && ctx.cursor.Inside(dc.Rect)
+}
+
+// MouseDown returns if the click input (UpdateSettings.LeftMouseClick) is held.
+func (dc *DrawCall) MouseDown() bool {
+	return dc.Context().updateSettings.LeftMouseClick
+}
+
+// MouseJustClicked returns if the click input (UpdateSettings.LeftMouseClick) was pressed this frame.
+func (dc *DrawCall) MouseJustClicked() bool {
+	return dc.Context().justClicked
+}
+
+// NavigationInput returns the navigation input pressed this frame if the element is highlighted.
+// If the element isn't highlighted or the input has been consumed, NavigationInputNone is returned.
+func (dc *DrawCall) NavigationInput() NavigationInput {
+	if !dc.isHighlighted {
+		return NavigationInputNone
+	}
+	return NavigationInput(dc.Context().queuedInput)
+}
+
+// ConsumeNavigationInput consumes the navigation input pressed this frame if the element is highlighted, so that
+// it doesn't also move the highlight to another element (like how a UISlider consumes left and right inputs).
+func (dc *DrawCall) ConsumeNavigationInput() {
+	if dc.isHighlighted {
+		dc.Context().queuedInput = queuedInputNone
+	}
+}
+
+// ResetState discards the state of the UI element being drawn, so that the next call to StateFor() creates it anew.
+func (dc *DrawCall) ResetState() {
+	dc.Instance.state = nil
+}
+
+// DrawChild draws another UI element as a child of the element being drawn, in the same Rect and with the same Color.
+// The idSuffix is appended to the element's ID to identify the child, and should be unique for each child of the element.
+// The child's draw call is returned so that its instance (and state) can be accessed.
+func (dc *DrawCall) DrawChild(idSuffix string, element UIElement) *DrawCall {
+	child := dc.Clone()
+	dc.Instance.layout.addChild(dc.Instance, idSuffix, -1, element, child)
+	return child
+}
@@ -0,0 +1,70 @@
+package gooey
+
+import "testing"
+
+// stepper is a Widget that changes its value with left and right input while it's highlighted, like a UISlider.
+type stepper struct{}
+
+type stepperState struct {
+	value int
+}
+
+func (s stepper) Highlightable() bool {
+	return true
+}
+
+func (s stepper) Draw(dc *DrawCall) {
+
+	state := StateFor[stepperState](dc)
+
+	switch dc.NavigationInput() {
+	case NavigationInputRight:
+		state.value++
+		dc.ConsumeNavigationInput()
+	case NavigationInputLeft:
+		state.value--
+		dc.ConsumeNavigationInput()
+	}
+
+}
+
+func TestWidgetConsumesNavigationInput(t *testing.T) {
+
+	ctx := newTestContext()
+
+	var state *stepperState
+
+	// Right is pressed twice, which would move the highlight to the Layout on the right if the stepper didn't
+	// consume it; then down moves past the disabled widget.
+	inputs := []UpdateSettings{{}, {RightInput: true}, {}, {RightInput: true}, {}, {DownInput: true}}
+
+	for frame, input := range inputs {
+
+		testFrame(ctx, func() {
+
+			menu := ctx.NewLayout("menu", 0, 0, 100, 60)
+			menu.SetArranger(ArrangerGrid{ElementCount: 1, ElementSize: Vector2{0, 20}})
+
+			state = NewUIWidget(stepper{}).AddTo(menu, "volume").(*stepperState)
+			NewUIWidget(testWidget{disabled: true}).AddTo(menu, "disabled")
+			NewUIWidget(testWidget{}).AddTo(menu, "back")
+
+			NewUIWidget(testWidget{}).AddTo(ctx.NewLayout("side", 200, 0, 100, 20), "help")
+
+			if frame == 0 {
+				ctx.Highlight(menu, "volume")
+			}
+
+		}, input)
+
+	}
+
+	if state.value != 2 {
+		t.Errorf("stepper's value is %d, want 2", state.value)
+	}
+
+	if h := ctx.HighlightedUIElement(); h == nil || h.id != "back" {
+		t.Errorf("highlighted %v, want back", h.ref())
+	}
+
+}
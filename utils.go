@@ -245,13 +245,13 @@ func SubImage(img *ebiten.Image, x, y, w, h float32) *ebiten.Image {
 }
 
 type sortedElementInstanceMap struct {
-	Data  map[string]*uiElementInstance
-	Order []string
+	Data  map[uint64]*uiElementInstance
+	Order []uint64
 }
 
 func newSortedElementInstanceMap() *sortedElementInstanceMap {
 	return &sortedElementInstanceMap{
-		Data: map[string]*uiElementInstance{},
+		Data: map[uint64]*uiElementInstance{},
 	}
 }
 
@@ -264,26 +264,47 @@ func (s *sortedElementInstanceMap) Clone() *sortedElementInstanceMap {
 	return newMap
 }
 
-func (s *sortedElementInstanceMap) Add(id string) *uiElementInstance {
+// Add returns the instance for the given ID hash, creating it if it doesn't exist yet.
+// The returned boolean indicates if the instance was newly created.
+func (s *sortedElementInstanceMap) Add(hash uint64) (*uiElementInstance, bool) {
 
-	if inst, exists := s.Data[id]; !exists {
+	if inst, exists := s.Data[hash]; !exists {
 		inst = &uiElementInstance{
-			id: id,
+			hash: hash,
 		}
-		s.Data[id] = inst
-		s.Order = append(s.Order, id)
-		return inst
+		s.Data[hash] = inst
+		s.Order = append(s.Order, hash)
+		return inst, true
 	} else {
-		return inst
+		return inst, false
 	}
 
 }
 
-func (s *sortedElementInstanceMap) Contains(id string) bool {
-	_, exists := s.Data[id]
+// RemoveFunc removes every instance for which the given function returns true, preserving the order of the rest.
+func (s *sortedElementInstanceMap) RemoveFunc(remove func(instance *uiElementInstance) bool) {
+	kept := s.Order[:0]
+	for _, hash := range s.Order {
+		if remove(s.Data[hash]) {
+			delete(s.Data, hash)
+		} else {
+			kept = append(kept, hash)
+		}
+	}
+	clear(s.Order[len(kept):])
+	s.Order = kept
+}
+
+func (s *sortedElementInstanceMap) Contains(hash uint64) bool {
+	_, exists := s.Data[hash]
 	return exists
 }
 
+// Get returns the instance for the given ID in the root ID scope, or nil if it doesn't exist.
+func (s *sortedElementInstanceMap) Get(id string) *uiElementInstance {
+	return s.Data[hashIDString(idHashSeed, id)]
+}
+
 func (s *sortedElementInstanceMap) ForEach(forEach func(instance *uiElementInstance) bool) {
 	for _, id := range s.Order {
 		if !forEach(s.Data[id]) {